@@ -13,8 +13,13 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
+var timeType = reflect.TypeOf(time.Time{})
+
 type Schema struct {
 	Type        string             `json:"type"`
 	Properties  map[string]*Schema `json:"properties,omitempty"`
@@ -27,6 +32,8 @@ type Schema struct {
 	Default     string             `json:"default,omitempty"`
 	Required    bool               `json:"required,omitempty"`
 	Binding     string             `json:"binding,omitempty"`
+	Visibility  Audience           `json:"visibility,omitempty"`
+	Deprecated  bool               `json:"deprecated,omitempty"`
 }
 
 type Api struct {
@@ -39,11 +46,58 @@ type Api struct {
 	RequestSchema  *Schema                         `json:"request_schema,omitempty"`
 	ResponseSchema *Schema                         `json:"response_schema,omitempty"`
 	ErrHandler     func(c *gin.Context, err error) `json:"-"`
-	unexported     bool
+	MockScenarios  []*MockScenario                 `json:"mock_scenarios,omitempty"`
+	Tags           []string                        `json:"tags,omitempty"`
+	Versions       []string                        `json:"versions,omitempty"`
+	Security       []*SecurityScheme               `json:"security,omitempty"`
+	Permissions    []string                        `json:"permissions,omitempty"`
+	Errors         []*ErrorDoc                     `json:"errors,omitempty"`
+	Visibility     Audience                        `json:"visibility,omitempty"`
+	Deprecated     *Deprecation                    `json:"deprecated,omitempty"`
+	// Async apis respond 202 with the operation to poll at Location, see RegisterAsyncAPI
+	Async      bool `json:"async,omitempty"`
+	unexported bool
+	mock           bool
+	group          *ApiGroup
+	middleware     []gin.HandlerFunc
+	// interceptors is []Interceptor[Req, Resp] set by WithInterceptors
+	interceptors any
 }
 
 type ApiGroup struct {
-	apis []*Api
+	apis     []*Api
+	webhooks []*Webhook
+	tags     []string
+	security []*SecurityScheme
+
+	authorizer Authorizer
+	common     *commonParams
+	errHandler func(c *gin.Context, err error)
+	middleware []gin.HandlerFunc
+
+	interceptors []GroupInterceptor
+
+	parent      *ApiGroup
+	children    []*ApiGroup
+	router      BasicRouter
+	title       string
+	description string
+
+	audienceResolver func(c *gin.Context) Audience
+	mock             atomic.Bool
+	mockSet          atomic.Bool
+
+	respValidate    atomic.Int32
+	respValidateSet atomic.Bool
+	respViolations  sync.Map
+
+	deprecatedCalls sync.Map
+	deprecatedHook  func(c *gin.Context, api *Api)
+
+	version       string
+	versions      []string
+	versioning    *Versioning
+	versionRoutes map[string]*versionRoute
 
 	vad *validator.Validate
 }
@@ -75,12 +129,19 @@ func (a *ApiGroup) Add(api *Api) {
 	a.apis = append(a.apis, api)
 }
 
-func (a *ApiGroup) GenerateMarkdown() string {
-	return GenerateMarkdown(a.apis)
+// Apis returns all registered apis
+func (a *ApiGroup) Apis() []*Api {
+	return a.apis
+}
+
+// GenerateMarkdown generates markdown docs for the audience, public by default
+func (a *ApiGroup) GenerateMarkdown(audience ...Audience) string {
+	return generateGroupFromTemplate(a, filterApis(a.apis, audienceOf(audience)), a.webhooks, markdownTlp)
 }
 
-func (a *ApiGroup) GenerateHtml() string {
-	return GenerateHtml(a.apis)
+// GenerateHtml generates html docs for the audience, public by default
+func (a *ApiGroup) GenerateHtml(audience ...Audience) string {
+	return generateGroupFromTemplate(a, filterApis(a.apis, audienceOf(audience)), a.webhooks, htmlTlp)
 }
 func boolOfStr(s string) bool {
 	r, _ := strconv.ParseBool(s)
@@ -89,35 +150,66 @@ func boolOfStr(s string) bool {
 func (a *ApiGroup) schemaHandler(api *Api) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if boolOfStr(c.Query("get_schema")) {
-			abortWithStatusJson(c, 200, api)
-			return
+			if v := api.view(a.audience(c)); v != nil {
+				abortWithStatusJson(c, 200, v)
+				return
+			}
 		}
 		c.Next()
 	}
 }
 
+var audiences = []Audience{AudiencePublic, AudiencePartner, AudienceInternal}
+
+// HandlerDocumentMd serves markdown docs of the audience resolved by SetAudienceResolver,
+// docs of versioned apis are served per version by query "version"
 func (a *ApiGroup) HandlerDocumentMd() gin.HandlerFunc {
-	markdown := a.GenerateMarkdown()
+	markdown := map[string]map[Audience]string{}
+	for v, g := range a.versionViews() {
+		markdown[v] = map[Audience]string{}
+		for _, aud := range audiences {
+			markdown[v][aud] = g.GenerateMarkdown(aud)
+		}
+	}
 	return func(c *gin.Context) {
+		docs, ok := markdown[a.requestedVersion(c)]
+		if !ok {
+			abortWithStatusJson(c, 404, &ErrorResponse{Error: "unknown version"})
+			return
+		}
 		c.Writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		c.Writer.WriteHeader(200)
-		c.Writer.WriteString(markdown)
+		c.Writer.WriteString(docs[a.audience(c)])
 	}
 }
 
+// HandlerDocumentHtml serves html docs of the audience resolved by SetAudienceResolver,
+// docs of versioned apis are served per version by query "version"
 func (a *ApiGroup) HandlerDocumentHtml() gin.HandlerFunc {
-	markdown := a.GenerateHtml()
+	markdown := map[string]map[Audience]string{}
+	for v, g := range a.versionViews() {
+		markdown[v] = map[Audience]string{}
+		for _, aud := range audiences {
+			markdown[v][aud] = g.GenerateHtml(aud)
+		}
+	}
 	return func(c *gin.Context) {
+		docs, ok := markdown[a.requestedVersion(c)]
+		if !ok {
+			abortWithStatusJson(c, 404, &ErrorResponse{Error: "unknown version"})
+			return
+		}
 		c.Writer.Header().Set("Content-Type", "text/html; charset=utf-8")
 		c.Writer.WriteHeader(200)
-		c.Writer.WriteString(markdown)
+		c.Writer.WriteString(docs[a.audience(c)])
 	}
 }
 
+// HandlerAllApiSchemas serves api schemas of the audience resolved by SetAudienceResolver
 func (a *ApiGroup) HandlerAllApiSchemas() gin.HandlerFunc {
 
 	return func(c *gin.Context) {
-		abortWithStatusJson(c, 200, a.apis)
+		abortWithStatusJson(c, 200, filterApis(a.apis, a.audience(c)))
 	}
 }
 
@@ -141,6 +233,13 @@ func WithErrHandler(f func(c *gin.Context, err error)) OptFunc {
 	}
 }
 
+// WithTags sets tags of the api, docs and exports are grouped by tags
+func WithTags(tags ...string) OptFunc {
+	return func(o *Api) {
+		o.Tags = append(o.Tags, tags...)
+	}
+}
+
 func WithUnExported() OptFunc {
 	return func(o *Api) {
 		o.unexported = true
@@ -172,15 +271,41 @@ func (a *ApiGroup) RegisterGin(router BasicRouter, reqTemplate any, resTemplate
 	for _, opt := range opts {
 		opt(api)
 	}
+	if api.interceptors != nil {
+		panic(fmt.Sprintf("interceptors of %s %s are only supported by RegisterAPI, use Intercept of the group", method, pth))
+	}
 	rsc.Description = api.Description
 
-	router.Handle(method, pth, a.schemaHandler(api), handler)
-	api.Route = path.Join(router.BasePath(), pth)
-	a.apis = append(a.apis, api)
+	a.applyDefaults(api)
+	a.handle(router, api, pth, handler)
 	return api
 }
 
-func RegisterAPI[Req, Resp any](r *ApiGroup, router BasicRouter, method, pth string, handler Handler[Req, Resp], opts ...OptFunc) {
+// SetDefaultTags sets tags of apis registered later without WithTags
+func (a *ApiGroup) SetDefaultTags(tags ...string) {
+	a.tags = tags
+}
+
+func (a *ApiGroup) handle(router BasicRouter, api *Api, pth string, handler gin.HandlerFunc) {
+	if len(api.Versions) > 0 {
+		a.handleVersions(router, api, pth, handler)
+		return
+	}
+	a.route(router, api, pth, handler)
+}
+
+func (a *ApiGroup) route(router BasicRouter, api *Api, pth string, handler gin.HandlerFunc) {
+	handlers := []gin.HandlerFunc{a.schemaHandler(api), a.deprecationHandler(api), a.securityHandler(api),
+		a.authorizeHandler(api), a.commonParamsHandler(api)}
+	handlers = append(handlers, a.middleware...)
+	handlers = append(handlers, api.middleware...)
+	handlers = append(handlers, a.responseValidator(api), a.mockHandler(api), handler)
+	router.Handle(api.Method, pth, handlers...)
+	api.Route = path.Join(router.BasePath(), pth)
+	a.add(api)
+}
+
+func RegisterAPI[Req, Resp any](r *ApiGroup, router BasicRouter, method, pth string, handler Handler[Req, Resp], opts ...OptFunc) *Api {
 
 	//r.RegisterGin(router,new(Req),new(Resp),method,pth, WrapHandler[Req, Resp](r, handler, r.ErrHandler))
 	r.testValidate(new(Req))
@@ -198,9 +323,9 @@ func RegisterAPI[Req, Resp any](r *ApiGroup, router BasicRouter, method, pth str
 	}
 	rsc.Description = a.Description
 
-	router.Handle(method, pth, r.schemaHandler(a), WrapHandler[Req, Resp](r, handler, a.ErrHandler))
-	a.Route = path.Join(router.BasePath(), pth)
-	r.apis = append(r.apis, a)
+	r.applyDefaults(a)
+	r.handle(router, a, pth, wrapHandler[Req, Resp](r, handler, a.ErrHandler, typedInterceptors[Req, Resp](r, a)))
+	return a
 }
 
 func RegisterAPIWithDoc[Req, Resp any](r *ApiGroup, router BasicRouter, method, pth string, handler Handler[Req, Resp], title string, desc string, opts ...OptFunc) {
@@ -404,6 +529,11 @@ func generateSchema(v reflect.Value, tags reflect.StructTag) *Schema {
 		return generateSchema(v.Elem(), tags)
 	case reflect.Struct:
 		t := v.Type()
+		if t == timeType {
+			return &Schema{
+				Type: "string",
+			}
+		}
 
 		sc := &Schema{
 			Type:       "object",
@@ -437,6 +567,8 @@ func generateSchema(v reflect.Value, tags reflect.StructTag) *Schema {
 				}
 			}
 			fsc.Binding = bind
+			fsc.Visibility = Audience(field.Tag.Get("visibility"))
+			fsc.Deprecated, _ = strconv.ParseBool(field.Tag.Get("deprecated"))
 			sc.Properties[jsonTag] = fsc
 
 		}
@@ -536,6 +668,7 @@ func (s *Schema) toDoc(path string) []*FiledDoc {
 			Location:    s.Location,
 			Default:     s.Default,
 			Binding:     s.Binding,
+			Deprecated:  s.Deprecated,
 		})
 	}
 
@@ -657,17 +790,7 @@ func (s *Schema) generateExamplePath(path string) string {
 	params := parsePathParams(path)
 	values := make([]string, len(params))
 	for i, param := range params {
-		s.Walk(func(name string, node *Schema) bool {
-			if name == param[1:] {
-				exp := node.getExample()
-				if exp == "" {
-					exp = name
-				}
-				values[i] = exp
-				return false
-			}
-			return true
-		})
+		values[i] = s.examplePathParam(param[1:])
 	}
 
 	rps := make([]string, 0, len(values)+len(params))
@@ -678,6 +801,21 @@ func (s *Schema) generateExamplePath(path string) string {
 	return strings.NewReplacer(rps...).Replace(path)
 }
 
+// examplePathParam returns example value of path param, the param name is used if no example is declared
+func (s *Schema) examplePathParam(param string) string {
+	value := param
+	s.Walk(func(name string, node *Schema) bool {
+		if name == param {
+			if exp := node.getExample(); exp != "" {
+				value = exp
+			}
+			return false
+		}
+		return true
+	})
+	return value
+}
+
 func (s *Schema) Walk(f func(name string, node *Schema) bool) {
 	for name, schema := range s.Properties {
 		if !f(name, schema) {
@@ -64,6 +64,7 @@ func (a *ApiGroup) RegisterAllApi(gg BasicRouter, apiStruct any, isapi func(func
 			outType := hdt.Out(0).Elem()
 
 			var errH ErrHandler
+			interceptors := a.interceptors
 
 			aaa := a.RegisterGin(gg, reflect.New(inType).Interface(), reflect.New(outType).Interface(),
 				aa.FieldByName("Method").String(), aa.FieldByName("Path").String(), func(ctx *gin.Context) {
@@ -77,11 +78,20 @@ func (a *ApiGroup) RegisterAllApi(gg BasicRouter, apiStruct any, isapi func(func
 						errH(ctx, err)
 						return
 					}
-					out := hd.Call([]reflect.Value{reflect.ValueOf(ctx), req})
-					res := out[0].Interface()
+					res, err := runInterceptors(ctx, interceptors, req.Interface(), func(r any) (any, error) {
+						if reflect.TypeOf(r) != req.Type() {
+							return nil, fmt.Errorf("interceptor replaced request with %T", r)
+						}
+						out := hd.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(r)})
+						return out[0].Interface(), nil
+					})
 					if ctx.IsAborted() {
 						return
 					}
+					if err != nil {
+						errH(ctx, err)
+						return
+					}
 
 					abortWithStatusJson(ctx, 200, res)
 				}, o...)
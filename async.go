@@ -0,0 +1,357 @@
+package swagger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	OperationPending   = "pending"
+	OperationRunning   = "running"
+	OperationSucceeded = "succeeded"
+	OperationFailed    = "failed"
+)
+
+var (
+	ErrOperationNotFound = errors.New("operation not found")
+	ErrWorkerPoolFull    = errors.New("worker pool is full")
+	ErrRunnerClosed      = errors.New("async runner is closed")
+)
+
+// Operation is the stored state of an async job, Result is the json encoded response.
+type Operation struct {
+	Id        string          `json:"id"`
+	Status    string          `json:"status"`
+	Error     string          `json:"error,omitempty"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type OperationStore interface {
+	Save(ctx context.Context, op *Operation) error
+	// Get returns ErrOperationNotFound if id does not exist
+	Get(ctx context.Context, id string) (*Operation, error)
+}
+
+type memoryOperationStore struct {
+	lock sync.RWMutex
+	ops  map[string]*Operation
+	ttl  time.Duration
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewMemoryOperationStore returns an in-memory store, finished operations older than ttl
+// are dropped by a sweep running every ttl, ttl <= 0 keeps them forever.
+// The store implements io.Closer to stop the sweep.
+func NewMemoryOperationStore(ttl time.Duration) OperationStore {
+	m := &memoryOperationStore{
+		ops:  map[string]*Operation{},
+		ttl:  ttl,
+		stop: make(chan struct{}),
+	}
+	if ttl > 0 {
+		go m.sweepLoop()
+	}
+	return m
+}
+
+func (m *memoryOperationStore) sweepLoop() {
+	ticker := time.NewTicker(m.ttl)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case now := <-ticker.C:
+			m.sweep(now)
+		}
+	}
+}
+
+// sweep drops finished operations not updated within ttl
+func (m *memoryOperationStore) sweep(now time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for id, o := range m.ops {
+		if (o.Status == OperationSucceeded || o.Status == OperationFailed) && now.Sub(o.UpdatedAt) > m.ttl {
+			delete(m.ops, id)
+		}
+	}
+}
+
+// Close stops the sweep, stored operations are kept
+func (m *memoryOperationStore) Close() error {
+	m.once.Do(func() {
+		close(m.stop)
+	})
+	return nil
+}
+
+func (m *memoryOperationStore) Save(ctx context.Context, op *Operation) error {
+	cp := *op
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ops[op.Id] = &cp
+	return nil
+}
+
+func (m *memoryOperationStore) Get(ctx context.Context, id string) (*Operation, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	op, ok := m.ops[id]
+	if !ok {
+		return nil, ErrOperationNotFound
+	}
+	cp := *op
+	return &cp, nil
+}
+
+// AsyncRunner runs async api jobs in a fixed size worker pool and records their state in Store.
+type AsyncRunner struct {
+	Store OperationStore
+
+	jobs   chan func(ctx context.Context)
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	// lock guards closed so that no job is queued after Close drained the queue
+	lock   sync.RWMutex
+	closed bool
+	// ownStore is the default store created by the runner, closed with it
+	ownStore io.Closer
+}
+
+// NewAsyncRunner starts workers goroutines, at most queueSize jobs can wait for a worker.
+// store defaults to an in-memory store keeping finished operations for one hour.
+func NewAsyncRunner(workers int, queueSize int, store OperationStore) *AsyncRunner {
+	if workers <= 0 {
+		workers = 1
+	}
+	r := &AsyncRunner{
+		Store: store,
+		jobs:  make(chan func(ctx context.Context), queueSize),
+	}
+	if store == nil {
+		r.Store = NewMemoryOperationStore(time.Hour)
+		r.ownStore = r.Store.(io.Closer)
+	}
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	for i := 0; i < workers; i++ {
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			for {
+				select {
+				case <-r.ctx.Done():
+					return
+				case job := <-r.jobs:
+					job(r.ctx)
+				}
+			}
+		}()
+	}
+	return r
+}
+
+// Close cancels the context of running jobs and waits for workers to exit. Jobs still waiting
+// in the queue are not run, their operations are marked failed. Jobs submitted later are rejected.
+func (r *AsyncRunner) Close() {
+	r.lock.Lock()
+	if r.closed {
+		r.lock.Unlock()
+		return
+	}
+	r.closed = true
+	r.lock.Unlock()
+
+	r.cancel()
+	r.wg.Wait()
+	for {
+		select {
+		case job := <-r.jobs:
+			// the context is done, the job only records the operation as failed
+			job(r.ctx)
+		default:
+			if r.ownStore != nil {
+				_ = r.ownStore.Close()
+			}
+			return
+		}
+	}
+}
+
+func (r *AsyncRunner) submit(job func(ctx context.Context)) error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if r.closed {
+		return ErrRunnerClosed
+	}
+	select {
+	case r.jobs <- job:
+		return nil
+	default:
+		return ErrWorkerPoolFull
+	}
+}
+
+type AsyncHandler[Req, Resp any] func(ctx context.Context, req *Req) (*Resp, error)
+
+type AsyncAccepted struct {
+	OperationId string `json:"operation_id" desc:"id of the created operation"`
+	Status      string `json:"status" enum:"pending" desc:"operation status"`
+	StatusUrl   string `json:"status_url" desc:"url to poll the operation status"`
+}
+
+type OperationResult[Resp any] struct {
+	Id        string    `json:"id" desc:"operation id"`
+	Status    string    `json:"status" enum:"pending,running,succeeded,failed" desc:"operation status"`
+	Error     string    `json:"error,omitempty" desc:"error message when status is failed"`
+	Result    *Resp     `json:"result,omitempty" desc:"handler response when status is succeeded"`
+	CreatedAt time.Time `json:"created_at" desc:"create time"`
+	UpdatedAt time.Time `json:"updated_at" desc:"last update time"`
+}
+
+type operationRequest struct {
+	Id string `json:"id" location:"path,id" desc:"operation id" binding:"required"`
+}
+
+// successStatus is the status code of successful responses of the api
+func (a *Api) successStatus() int {
+	if a.Async {
+		return 202
+	}
+	return 200
+}
+
+// RegisterAsyncAPI registers an api that binds Req, answers 202 with an operation id and runs handler
+// in runner. A typed GET {pth}/operations/:id api is registered to poll the result, it has the security,
+// permissions, visibility, versions and middlewares of the api.
+func RegisterAsyncAPI[Req, Resp any](r *ApiGroup, runner *AsyncRunner, router BasicRouter, method, pth string, handler AsyncHandler[Req, Resp], opts ...OptFunc) *Api {
+	var inherit OptFunc
+	opts = append(opts, func(o *Api) {
+		o.Async = true
+		o.Description += "\n\nasync api: responds 202 with operation_id, poll status_url for the result"
+		o.Errors = append(o.Errors, &ErrorDoc{Status: 503, Description: "worker pool is full",
+			Example: &ErrorResponse{Error: ErrWorkerPoolFull.Error()}})
+		security, permissions, visibility := o.Security, o.Permissions, o.Visibility
+		versions, middleware := o.Versions, o.middleware
+		inherit = func(s *Api) {
+			s.Security, s.Permissions, s.Visibility, s.Versions = security, permissions, visibility, versions
+			s.middleware = append(s.middleware, middleware...)
+		}
+	})
+	api := RegisterAPI(r, router, method, pth, func(ctx *gin.Context, req *Req) *AsyncAccepted {
+		id, err := randomId()
+		if err != nil {
+			abortWithStatusJson(ctx, 500, &ErrorResponse{Error: err.Error()})
+			return nil
+		}
+		now := time.Now()
+		op := &Operation{Id: id, Status: OperationPending, CreatedAt: now, UpdatedAt: now}
+		if err = runner.Store.Save(ctx, op); err != nil {
+			abortWithStatusJson(ctx, 500, &ErrorResponse{Error: err.Error()})
+			return nil
+		}
+		err = runner.submit(func(jctx context.Context) {
+			runOperation(jctx, runner.Store, op, req, handler)
+		})
+		if err != nil {
+			op.Status = OperationFailed
+			op.Error = err.Error()
+			op.UpdatedAt = time.Now()
+			_ = runner.Store.Save(ctx, op)
+			abortWithStatusJson(ctx, 503, &ErrorResponse{Error: err.Error()})
+			return nil
+		}
+		statusUrl := operationUrl(ctx, id)
+		ctx.Header("Location", statusUrl)
+		abortWithStatusJson(ctx, 202, &AsyncAccepted{
+			OperationId: id,
+			Status:      OperationPending,
+			StatusUrl:   statusUrl,
+		})
+		return nil
+	}, opts...)
+
+	RegisterAPI(r, router, "GET", path.Join(pth, "operations/:id"), func(ctx *gin.Context, req *operationRequest) *OperationResult[Resp] {
+		op, err := runner.Store.Get(ctx, req.Id)
+		if err != nil {
+			status := 500
+			if errors.Is(err, ErrOperationNotFound) {
+				status = 404
+			}
+			abortWithStatusJson(ctx, status, &ErrorResponse{Error: err.Error()})
+			return nil
+		}
+		res := &OperationResult[Resp]{
+			Id:        op.Id,
+			Status:    op.Status,
+			Error:     op.Error,
+			CreatedAt: op.CreatedAt,
+			UpdatedAt: op.UpdatedAt,
+		}
+		if len(op.Result) > 0 {
+			res.Result = new(Resp)
+			if err = json.Unmarshal(op.Result, res.Result); err != nil {
+				abortWithStatusJson(ctx, 500, &ErrorResponse{Error: err.Error()})
+				return nil
+			}
+		}
+		return res
+	}, WithTitle(api.Title+" status"), WithDescription("get the status and result of operation created by "+api.Method+" "+api.Route), WithErrHandler(api.ErrHandler), WithTags(api.Tags...), inherit)
+	return api
+}
+
+// operationUrl returns the status url of operation id under the route serving the request, path params are filled
+func operationUrl(ctx *gin.Context, id string) string {
+	segs := strings.Split(ctx.FullPath(), "/")
+	for i, seg := range segs {
+		if seg != "" && (seg[0] == ':' || seg[0] == '*') {
+			segs[i] = strings.Trim(ctx.Param(seg[1:]), "/")
+		}
+	}
+	return path.Join(strings.Join(segs, "/"), "operations", id)
+}
+
+func runOperation[Req, Resp any](ctx context.Context, store OperationStore, op *Operation, req *Req, handler AsyncHandler[Req, Resp]) {
+	if ctx.Err() != nil {
+		op.Status = OperationFailed
+		op.Error = ErrRunnerClosed.Error()
+		op.UpdatedAt = time.Now()
+		_ = store.Save(context.Background(), op)
+		return
+	}
+	op.Status = OperationRunning
+	op.UpdatedAt = time.Now()
+	_ = store.Save(ctx, op)
+
+	res, err := func() (res *Resp, err error) {
+		defer func() {
+			if e := recover(); e != nil {
+				err = fmt.Errorf("operation panic: %v", e)
+			}
+		}()
+		return handler(ctx, req)
+	}()
+	if err == nil {
+		op.Result, err = JsonMarshal(res)
+	}
+	if err != nil {
+		op.Status = OperationFailed
+		op.Error = err.Error()
+	} else {
+		op.Status = OperationSucceeded
+	}
+	op.UpdatedAt = time.Now()
+	_ = store.Save(context.Background(), op)
+}
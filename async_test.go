@@ -0,0 +1,182 @@
+package swagger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type exportRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type exportResponse struct {
+	File string `json:"file"`
+}
+
+func TestAsyncAPI(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	runner := NewAsyncRunner(2, 10, nil)
+	defer runner.Close()
+
+	RegisterAsyncAPI(group, runner, gine.Group("/api"), "POST", "/exports", func(ctx context.Context, req *exportRequest) (*exportResponse, error) {
+		if req.Name == "bad" {
+			return nil, errors.New("export failed")
+		}
+		return &exportResponse{File: req.Name + ".csv"}, nil
+	}, WithTitle("export"))
+
+	if len(group.apis) != 2 || group.apis[1].Route != "/api/exports/operations/:id" {
+		t.Fatalf("status api is not registered: %+v", group.apis)
+	}
+
+	poll := func(body string) *OperationResult[exportResponse] {
+		w := httptest.NewRecorder()
+		gine.ServeHTTP(w, httptest.NewRequest("POST", "/api/exports", strings.NewReader(body)))
+		if w.Code != 202 {
+			t.Fatalf("expect 202, got %d %s", w.Code, w.Body.String())
+		}
+		accepted := &AsyncAccepted{}
+		_ = json.Unmarshal(w.Body.Bytes(), accepted)
+		for i := 0; i < 100; i++ {
+			w = httptest.NewRecorder()
+			gine.ServeHTTP(w, httptest.NewRequest("GET", accepted.StatusUrl, nil))
+			res := &OperationResult[exportResponse]{}
+			_ = json.Unmarshal(w.Body.Bytes(), res)
+			if res.Status == OperationSucceeded || res.Status == OperationFailed {
+				return res
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatal("operation did not finish")
+		return nil
+	}
+
+	if res := poll(`{"name":"users"}`); res.Result == nil || res.Result.File != "users.csv" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if res := poll(`{"name":"bad"}`); res.Status != OperationFailed || res.Error != "export failed" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+
+	w := httptest.NewRecorder()
+	gine.ServeHTTP(w, httptest.NewRequest("GET", "/api/exports/operations/missing", nil))
+	if w.Code != 404 {
+		t.Fatalf("expect 404, got %d", w.Code)
+	}
+}
+
+func TestAsyncRunnerClose(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	store := NewMemoryOperationStore(0)
+	runner := NewAsyncRunner(1, 10, store)
+	started := make(chan struct{})
+	RegisterAsyncAPI(group, runner, gine, "POST", "/exports", func(ctx context.Context, req *exportRequest) (*exportResponse, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, WithTitle("export"))
+
+	submit := func() (int, string) {
+		w := httptest.NewRecorder()
+		gine.ServeHTTP(w, httptest.NewRequest("POST", "/exports", strings.NewReader(`{"name":"a"}`)))
+		accepted := &AsyncAccepted{}
+		_ = json.Unmarshal(w.Body.Bytes(), accepted)
+		return w.Code, accepted.OperationId
+	}
+	ids := []string{}
+	for i := 0; i < 3; i++ {
+		_, id := submit()
+		ids = append(ids, id)
+		if i == 0 {
+			<-started
+		}
+	}
+	runner.Close()
+	for _, id := range ids {
+		op, err := store.Get(context.Background(), id)
+		if err != nil || op.Status != OperationFailed {
+			t.Errorf("operation %s should be failed after close: %+v %v", id, op, err)
+		}
+	}
+	if code, _ := submit(); code != 503 {
+		t.Errorf("submit after close should be rejected, got %d", code)
+	}
+}
+
+func TestMemoryOperationStoreSweep(t *testing.T) {
+	store := NewMemoryOperationStore(time.Minute).(*memoryOperationStore)
+	defer store.Close()
+	now := time.Now()
+	ctx := context.Background()
+	_ = store.Save(ctx, &Operation{Id: "old", Status: OperationSucceeded, UpdatedAt: now.Add(-2 * time.Minute)})
+	_ = store.Save(ctx, &Operation{Id: "running", Status: OperationRunning, UpdatedAt: now.Add(-2 * time.Minute)})
+	_ = store.Save(ctx, &Operation{Id: "new", Status: OperationFailed, UpdatedAt: now})
+	if _, err := store.Get(ctx, "old"); err != nil {
+		t.Error("save should not drop expired operations")
+	}
+	store.sweep(now)
+	if _, err := store.Get(ctx, "old"); !errors.Is(err, ErrOperationNotFound) {
+		t.Error("expired finished operations should be swept")
+	}
+	for _, id := range []string{"running", "new"} {
+		if _, err := store.Get(ctx, id); err != nil {
+			t.Errorf("%s should be kept: %v", id, err)
+		}
+	}
+}
+
+func TestAsyncAPIInheritsOptions(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	runner := NewAsyncRunner(1, 10, nil)
+	defer runner.Close()
+	bearer := BearerAuth("bearerAuth", func(c *gin.Context, token string) (any, error) {
+		if token != "t1" {
+			return nil, errors.New("invalid token")
+		}
+		return token, nil
+	})
+	RegisterAsyncAPI(group, runner, gine.Group("/api"), "POST", "/jobs", func(ctx context.Context, req *exportRequest) (*exportResponse, error) {
+		return &exportResponse{File: req.Name}, nil
+	}, WithTitle("job"), WithSecurity(bearer), WithVisibility(AudienceInternal), WithVersions("v1", "v2"))
+
+	do := func(method, url, body, token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, url, strings.NewReader(body))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		gine.ServeHTTP(w, r)
+		return w
+	}
+	w := do("POST", "/api/v1/jobs", `{"name":"a"}`, "t1")
+	accepted := &AsyncAccepted{}
+	_ = json.Unmarshal(w.Body.Bytes(), accepted)
+	if w.Code != 202 || accepted.StatusUrl != "/api/v1/jobs/operations/"+accepted.OperationId ||
+		w.Header().Get("Location") != accepted.StatusUrl {
+		t.Fatalf("unexpected accepted response: %d %s %s", w.Code, w.Body.String(), w.Header().Get("Location"))
+	}
+	if w = do("GET", accepted.StatusUrl, "", ""); w.Code != 401 {
+		t.Errorf("status api should require the job security, got %d %s", w.Code, w.Body.String())
+	}
+	if w = do("GET", accepted.StatusUrl, "", "t1"); w.Code != 200 {
+		t.Errorf("status url should be served, got %d %s", w.Code, w.Body.String())
+	}
+	if md := group.GenerateMarkdown(); strings.Contains(md, "operations") {
+		t.Errorf("status api of internal job should be hidden from public docs:\n%s", md)
+	}
+
+	op := group.GenerateOpenAPI("api", "1.0.0", AudienceInternal).Paths["/api/v1/jobs"]["post"]
+	if op == nil || op.Responses["202"] == nil || op.Responses["202"].Headers["Location"] == nil ||
+		op.Responses["200"] != nil || op.Responses["503"] == nil {
+		t.Errorf("openapi should document 202 with Location and 503: %s", mustJson(op))
+	}
+}
@@ -0,0 +1,110 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Audience is the visibility level of apis and fields in docs, an audience sees everything up to its own level
+type Audience string
+
+const (
+	AudiencePublic   Audience = "public"
+	AudiencePartner  Audience = "partner"
+	AudienceInternal Audience = "internal"
+)
+
+func (a Audience) level() int {
+	switch a {
+	case AudiencePartner:
+		return 1
+	case AudienceInternal:
+		return 2
+	}
+	return 0
+}
+
+// CanSee reports whether the audience can see docs of visibility v, empty visibility is public
+func (a Audience) CanSee(v Audience) bool {
+	return a.level() >= v.level()
+}
+
+// WithVisibility sets the lowest audience the api is documented for, fields are hidden by tag `visibility:"internal"`
+func WithVisibility(v Audience) OptFunc {
+	return func(o *Api) {
+		o.Visibility = v
+	}
+}
+
+// SetAudienceResolver sets the check deciding which view the doc, schema, spec and client handlers serve,
+// AudiencePublic is served by default.
+func (a *ApiGroup) SetAudienceResolver(f func(c *gin.Context) Audience) {
+	a.audienceResolver = f
+}
+
+// audience resolves the audience by the resolver of the group or its nearest parent
+func (a *ApiGroup) audience(c *gin.Context) Audience {
+	for g := a; g != nil; g = g.parent {
+		if g.audienceResolver != nil {
+			return audiences[g.audienceResolver(c).level()]
+		}
+	}
+	return AudiencePublic
+}
+
+// AudienceFromHeader resolves the audience by the token in header, public is returned for unknown tokens
+func AudienceFromHeader(header string, tokens map[string]Audience) func(c *gin.Context) Audience {
+	return func(c *gin.Context) Audience {
+		if aud, ok := tokens[c.GetHeader(header)]; ok {
+			return aud
+		}
+		return AudiencePublic
+	}
+}
+
+func audienceOf(audience []Audience) Audience {
+	if len(audience) > 0 {
+		return audience[0]
+	}
+	return AudiencePublic
+}
+
+// filterApis returns the views of apis visible to the audience
+func filterApis(apis []*Api, audience Audience) []*Api {
+	res := []*Api{}
+	for _, api := range apis {
+		if v := api.view(audience); v != nil {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// view returns a copy of api without the fields hidden from the audience, nil if the api is hidden
+func (a *Api) view(audience Audience) *Api {
+	if !audience.CanSee(a.Visibility) {
+		return nil
+	}
+	v := *a
+	v.RequestSchema = a.RequestSchema.view(audience)
+	v.ResponseSchema = a.ResponseSchema.view(audience)
+	return &v
+}
+
+func (s *Schema) view(audience Audience) *Schema {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	if s.Items != nil {
+		v.Items = s.Items.view(audience)
+	}
+	if s.Properties != nil {
+		v.Properties = map[string]*Schema{}
+		for name, p := range s.Properties {
+			if audience.CanSee(p.Visibility) {
+				v.Properties[name] = p.view(audience)
+			}
+		}
+	}
+	return &v
+}
@@ -0,0 +1,116 @@
+package swagger
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type audienceResponse struct {
+	Name  string `json:"name" desc:"public name"`
+	Quota int    `json:"quota" desc:"partner quota" visibility:"partner"`
+	Trace string `json:"trace" desc:"internal trace" visibility:"internal"`
+}
+
+func TestAudience(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	RegisterAPI(group, gine, "GET", "/users", func(ctx *gin.Context, req *struct{}) *audienceResponse {
+		return &audienceResponse{}
+	}, WithTitle("list users"))
+	RegisterAPI(group, gine, "GET", "/partners", func(ctx *gin.Context, req *struct{}) *audienceResponse {
+		return &audienceResponse{}
+	}, WithTitle("list partners"), WithVisibility(AudiencePartner))
+	RegisterAPI(group, gine, "POST", "/debug", func(ctx *gin.Context, req *struct{}) *audienceResponse {
+		return &audienceResponse{}
+	}, WithTitle("debug"), WithVisibility(AudienceInternal))
+
+	md := group.GenerateMarkdown()
+	if !strings.Contains(md, "list users") || strings.Contains(md, "list partners") || strings.Contains(md, "debug") ||
+		strings.Contains(md, "partner quota") || strings.Contains(md, "internal trace") {
+		t.Errorf("public markdown shows hidden docs:\n%s", md)
+	}
+	md = group.GenerateMarkdown(AudiencePartner)
+	if !strings.Contains(md, "list partners") || !strings.Contains(md, "partner quota") || strings.Contains(md, "internal trace") {
+		t.Errorf("unexpected partner markdown:\n%s", md)
+	}
+	html := group.GenerateHtml(AudienceInternal)
+	if !strings.Contains(html, "debug") || !strings.Contains(html, "internal trace") {
+		t.Error("internal html should show everything")
+	}
+	if _, ok := group.Apis()[0].ResponseSchema.Properties["trace"]; !ok {
+		t.Error("views should not modify registered schemas")
+	}
+
+	group.SetAudienceResolver(AudienceFromHeader("X-Doc-Token", map[string]Audience{"p-token": AudiencePartner}))
+	gine.GET("/apischema", group.HandlerAllApiSchemas())
+	gine.GET("/apidoc.md", group.HandlerDocumentMd())
+	get := func(url, token string) string {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", url, nil)
+		r.Header.Set("X-Doc-Token", token)
+		gine.ServeHTTP(w, r)
+		return w.Body.String()
+	}
+	apis := []*Api{}
+	_ = json.Unmarshal([]byte(get("/apischema", "p-token")), &apis)
+	if len(apis) != 2 || apis[1].ResponseSchema.Properties["trace"] != nil || apis[1].ResponseSchema.Properties["quota"] == nil {
+		t.Errorf("unexpected partner schemas: %s", get("/apischema", "p-token"))
+	}
+	if body := get("/apidoc.md", "bad"); strings.Contains(body, "list partners") {
+		t.Error("unknown token should get public docs")
+	}
+	if body := get("/users?get_schema=true", ""); strings.Contains(body, "trace") {
+		t.Errorf("get_schema should serve the public view: %s", body)
+	}
+}
+
+func TestAudienceExports(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	RegisterAPI(group, gine, "GET", "/users", func(ctx *gin.Context, req *struct{}) *audienceResponse {
+		return &audienceResponse{}
+	}, WithTitle("list users"))
+	RegisterAPI(group, gine, "POST", "/debug", func(ctx *gin.Context, req *struct{}) *audienceResponse {
+		return &audienceResponse{}
+	}, WithTitle("debug"), WithVisibility(AudienceInternal))
+
+	spec := group.GenerateOpenAPI("api", "1.0.0")
+	if _, ok := spec.Paths["/debug"]; ok || strings.Contains(string(mustJson(spec)), "internal trace") {
+		t.Error("public openapi should hide internal apis and fields")
+	}
+	if _, ok := group.GenerateOpenAPI("api", "1.0.0", AudienceInternal).Paths["/debug"]; !ok {
+		t.Error("internal openapi should show internal apis")
+	}
+	if col := group.GeneratePostmanCollection(); len(col.Item) != 1 {
+		t.Errorf("public postman collection should hide internal apis: %d", len(col.Item))
+	}
+	if ts := group.GenerateTypeScript(); strings.Contains(ts, "trace") || strings.Contains(ts, "/debug") {
+		t.Errorf("public typescript client should hide internal docs:\n%s", ts)
+	}
+	if py := group.GeneratePython(); strings.Contains(py, "trace") || strings.Contains(py, "/debug") {
+		t.Errorf("public python client should hide internal docs:\n%s", py)
+	}
+	if src, err := group.GenerateGoClient("client"); err != nil || strings.Contains(string(src), "/debug") {
+		t.Errorf("public go client should hide internal apis: %v", err)
+	}
+
+	group.SetAudienceResolver(AudienceFromHeader("X-Doc-Token", map[string]Audience{"i-token": AudienceInternal}))
+	gine.GET("/openapi.json", group.HandlerOpenAPI("api", "1.0.0"))
+	gine.GET("/postman.json", group.HandlerPostmanCollection())
+	gine.GET("/api.ts", group.HandlerTypeScript())
+	gine.GET("/swagger/*any", group.HandlerSwaggerUI("/swagger"))
+	for _, url := range []string{"/openapi.json", "/postman.json", "/api.ts", "/swagger/openapi.json"} {
+		for token, visible := range map[string]bool{"": false, "i-token": true} {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", url, nil)
+			r.Header.Set("X-Doc-Token", token)
+			gine.ServeHTTP(w, r)
+			if strings.Contains(w.Body.String(), "debug") != visible {
+				t.Errorf("%s with token %q should show internal apis: %v", url, token, visible)
+			}
+		}
+	}
+}
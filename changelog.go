@@ -0,0 +1,105 @@
+package swagger
+
+import (
+	"bytes"
+	_ "embed"
+	"text/template"
+)
+
+//go:embed changelog_template.md
+var changelogMarkdownTlp string
+
+//go:embed changelog_template.html
+var changelogHtmlTlp string
+
+type changelogData struct {
+	Title    string
+	Added    []*Api
+	Removed  []*Api
+	Changed  []*changelogApi
+	Breaking int
+}
+
+type changelogApi struct {
+	Api      *Api
+	Old      *Api
+	Breaking bool
+	// Description is set if title or description is changed
+	Description *ApiChange
+	Request     []*ApiChange
+	Response    []*ApiChange
+}
+
+// GenerateChangelog generates markdown change notes between two snapshots of apis
+func GenerateChangelog(old, new []*Api, title string) string {
+	return generateChangelog(old, new, title, changelogMarkdownTlp)
+}
+
+// GenerateChangelogHtml generates html change notes between two snapshots of apis
+func GenerateChangelogHtml(old, new []*Api, title string) string {
+	return generateChangelog(old, new, title, changelogHtmlTlp)
+}
+
+func newChangelogData(old, new []*Api, title string) *changelogData {
+	apis := map[string]*Api{}
+	for _, a := range old {
+		apis["old "+apiKey(a)] = a
+	}
+	for _, a := range new {
+		apis["new "+apiKey(a)] = a
+	}
+	data := &changelogData{Title: title}
+	changed := map[string]*changelogApi{}
+	for _, c := range DiffApis(old, new).Changes {
+		key := c.Method + " " + c.Route
+		switch c.Kind {
+		case ChangeAddApi:
+			if a := apis["new "+key]; !a.unexported {
+				data.Added = append(data.Added, a)
+			}
+			continue
+		case ChangeRemoveApi:
+			if a := apis["old "+key]; !a.unexported {
+				data.Removed = append(data.Removed, a)
+				data.Breaking++
+			}
+			continue
+		}
+		a := apis["new "+key]
+		if a.unexported {
+			continue
+		}
+		ca := changed[key]
+		if ca == nil {
+			ca = &changelogApi{Api: a, Old: apis["old "+key]}
+			changed[key] = ca
+			data.Changed = append(data.Changed, ca)
+		}
+		if c.Breaking {
+			ca.Breaking = true
+			data.Breaking++
+		}
+		switch {
+		case c.Kind == ChangeDescription:
+			ca.Description = c
+		case c.Part == PartRequest:
+			ca.Request = append(ca.Request, c)
+		default:
+			ca.Response = append(ca.Response, c)
+		}
+	}
+	return data
+}
+
+func generateChangelog(old, new []*Api, title string, tlp string) string {
+	t, err := template.New("changelog").Parse(tlp)
+	if err != nil {
+		panic(err)
+	}
+	bf := &bytes.Buffer{}
+	err = t.Execute(bf, newChangelogData(old, new, title))
+	if err != nil {
+		panic(err)
+	}
+	return bf.String()
+}
@@ -0,0 +1,42 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"strings"
+	"testing"
+)
+
+func TestGenerateChangelog(t *testing.T) {
+	v1 := NewAPIGroup()
+	RegisterAPI(v1, gin.New(), "POST", "/users/:id", func(ctx *gin.Context, req *diffRequestV1) *diffResponseV1 { return nil }, WithTitle("update user"))
+	RegisterAPI(v1, gin.New(), "DELETE", "/users/:id", func(ctx *gin.Context, req *diffRequestV1) *diffResponseV1 { return nil }, WithTitle("delete user"))
+	v2 := NewAPIGroup()
+	RegisterAPI(v2, gin.New(), "POST", "/users/:id", func(ctx *gin.Context, req *diffRequestV2) *diffResponseV2 { return nil }, WithTitle("update user"), WithDescription("update a user"))
+	RegisterAPI(v2, gin.New(), "GET", "/users", func(ctx *gin.Context, req *diffRequestV2) *diffResponseV2 { return nil }, WithTitle("list users"))
+	RegisterAPI(v2, gin.New(), "GET", "/internal", func(ctx *gin.Context, req *diffRequestV2) *diffResponseV2 { return nil }, WithUnExported())
+
+	md := GenerateChangelog(v1.Apis(), v2.Apis(), "v2.0.0")
+	for _, s := range []string{
+		"# v2.0.0",
+		"> 本次变更包含 6 项不兼容变更",
+		"- `GET /users` list users",
+		"- **[不兼容]** `DELETE /users/:id` delete user",
+		"#### update user **[不兼容]**",
+		"- 新: update user update a user",
+		"|phone|json||string|required field is added|不兼容|",
+		"|email|json|string||field is removed|兼容|",
+		"|score|integer||field is removed|不兼容|",
+	} {
+		if !strings.Contains(md, s) {
+			t.Errorf("changelog should contain %q:\n%s", s, md)
+		}
+	}
+	if strings.Contains(md, "/internal") {
+		t.Error("unexported api should not be in changelog")
+	}
+
+	html := GenerateChangelogHtml(v1.Apis(), v2.Apis(), "v2.0.0")
+	if !strings.Contains(html, "<h2>删除接口</h2>") || !strings.Contains(html, "<td>required field is added</td>") {
+		t.Errorf("unexpected html changelog:\n%s", html)
+	}
+}
@@ -0,0 +1,34 @@
+// swagger-mock serves mocked apis from the json dumped by ApiGroup.HandlerAllApiSchemas
+//
+//	curl http://localhost:8902/apischema > apischema.json
+//	swagger-mock -f apischema.json -addr :8080
+package main
+
+import (
+	"flag"
+	"github.com/gin-gonic/gin"
+	"github.com/seeadoog/swagger"
+	"log"
+	"os"
+)
+
+func main() {
+	file := flag.String("f", "apischema.json", "api schema file dumped from /apischema")
+	addr := flag.String("addr", ":8080", "listen address")
+	flag.Parse()
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("read api schema file error: %v", err)
+	}
+	engine := gin.New()
+	apiGroup, err := swagger.RegisterMockApis(engine, data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	engine.GET("/apidoc.html", apiGroup.HandlerDocumentHtml())
+	engine.GET("/apidoc.md", apiGroup.HandlerDocumentMd())
+	engine.GET("/apischema", apiGroup.HandlerAllApiSchemas())
+	engine.GET("/swagger/*any", apiGroup.HandlerSwaggerUI("/swagger"))
+	log.Fatal(engine.Run(*addr))
+}
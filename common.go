@@ -0,0 +1,71 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"reflect"
+)
+
+const commonParamsKey = "swagger.commonParams"
+
+// commonParams are parameters bound on every api of a group
+type commonParams struct {
+	typ    reflect.Type
+	schema *Schema
+}
+
+// SetCommonParams declares parameters shared by apis registered later, e.g. request id and tenant headers.
+// T is a struct of header, query or path fields with location tags, it is bound and validated
+// before the handler of every api and merged into the documented request schema.
+func SetCommonParams[T any](a *ApiGroup) {
+	a.testValidate(new(T))
+	a.common = &commonParams{
+		typ:    reflect.TypeOf(new(T)).Elem(),
+		schema: generateSchema(reflect.ValueOf(new(T)), ""),
+	}
+}
+
+// CommonParams returns the common params of the request bound as T, nil if not declared by SetCommonParams[T]
+func CommonParams[T any](c *gin.Context) *T {
+	v, _ := c.Get(commonParamsKey)
+	p, _ := v.(*T)
+	return p
+}
+
+// mergeInto returns a copy of schema with common params added, params declared by the api are kept
+func (p *commonParams) mergeInto(schema *Schema) *Schema {
+	s := *schema
+	s.Properties = map[string]*Schema{}
+	for name, prop := range p.schema.Properties {
+		s.Properties[name] = prop
+	}
+	for name, prop := range schema.Properties {
+		s.Properties[name] = prop
+	}
+	return &s
+}
+
+func (a *ApiGroup) commonParamsHandler(api *Api) gin.HandlerFunc {
+	common := a.common
+	return func(c *gin.Context) {
+		if common == nil {
+			c.Next()
+			return
+		}
+		v := reflect.New(common.typ)
+		err := bindPath(c, v)
+		if err == nil {
+			err = a.vad.Struct(v.Interface())
+		}
+		if err != nil {
+			errHandler := api.ErrHandler
+			if errHandler == nil {
+				errHandler = defaultErrHandler
+			}
+			errHandler(c, err)
+			c.Abort()
+			return
+		}
+		c.Set(commonParamsKey, v.Interface())
+		c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type tenantParams struct {
+	RequestId string `location:"header,X-Request-Id" desc:"request id" example:"req-1"`
+	Tenant    string `location:"header,X-Tenant" binding:"required" desc:"tenant id" example:"acme"`
+}
+
+type tenantRequest struct {
+	Name string `json:"name" desc:"user name"`
+}
+
+type tenantResponse struct {
+	Tenant    string `json:"tenant"`
+	RequestId string `json:"request_id"`
+}
+
+func TestCommonParams(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	SetCommonParams[tenantParams](group)
+	RegisterAPI(group, gine, "POST", "/users", func(ctx *gin.Context, req *tenantRequest) *tenantResponse {
+		p := CommonParams[tenantParams](ctx)
+		return &tenantResponse{Tenant: p.Tenant, RequestId: p.RequestId}
+	}, WithTitle("create user"))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/users", strings.NewReader(`{"name":"bob"}`))
+	r.Header.Set("X-Tenant", "acme")
+	r.Header.Set("X-Request-Id", "req-9")
+	gine.ServeHTTP(w, r)
+	if w.Code != 200 || w.Body.String() != `{"tenant":"acme","request_id":"req-9"}` {
+		t.Errorf("unexpected response: %d %s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	gine.ServeHTTP(w, httptest.NewRequest("POST", "/users", strings.NewReader(`{}`)))
+	if w.Code != 400 || !strings.Contains(w.Body.String(), "X-Tenant") {
+		t.Errorf("missing common header should be rejected: %d %s", w.Code, w.Body.String())
+	}
+
+	api := group.Apis()[0]
+	if p := api.RequestSchema.Properties["X-Tenant"]; p == nil || p.Location != "header" || p.Binding != "required" {
+		t.Errorf("common params should be merged into request schema: %v", p)
+	}
+	md := group.GenerateMarkdown()
+	if !strings.Contains(md, "|X-Tenant|string|") || !strings.Contains(md, "X-Tenant: acme") {
+		t.Errorf("markdown should document common params:\n%s", md)
+	}
+}
@@ -0,0 +1,106 @@
+package swagger
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Deprecation describes the retirement of an api, Replacement is the route of the successor api, e.g. "POST /v2/users"
+type Deprecation struct {
+	Since       *time.Time `json:"since,omitempty"`
+	Sunset      *time.Time `json:"sunset,omitempty"`
+	Replacement string     `json:"replacement,omitempty"`
+}
+
+// WithDeprecated marks the api deprecated since the time and removed at sunset, zero times are omitted.
+// Responses carry Deprecation, Sunset and Link headers, fields are deprecated by tag `deprecated:"true"`.
+func WithDeprecated(since, sunset time.Time, replacement string) OptFunc {
+	return func(o *Api) {
+		d := &Deprecation{Replacement: replacement}
+		if !since.IsZero() {
+			d.Since = &since
+		}
+		if !sunset.IsZero() {
+			d.Sunset = &sunset
+		}
+		o.Deprecated = d
+	}
+}
+
+// replacementPath returns the path of replacement without method
+func (d *Deprecation) replacementPath() string {
+	if _, pth, ok := strings.Cut(d.Replacement, " "); ok {
+		return pth
+	}
+	return d.Replacement
+}
+
+// SinceDate and SunsetDate format the times for docs
+func (d *Deprecation) SinceDate() string {
+	return formatDate(d.Since)
+}
+
+func (d *Deprecation) SunsetDate() string {
+	return formatDate(d.Sunset)
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// OnDeprecatedCall sets a hook called on each call of deprecated apis, e.g. to record the caller.
+// Sub-groups without their own hook use the one of the nearest parent.
+func (a *ApiGroup) OnDeprecatedCall(f func(c *gin.Context, api *Api)) {
+	a.deprecatedHook = f
+}
+
+// DeprecatedCalls returns call counts of deprecated apis in the group and its sub-groups keyed by "METHOD route"
+func (a *ApiGroup) DeprecatedCalls() map[string]int64 {
+	res := map[string]int64{}
+	a.deprecatedCalls.Range(func(key, value any) bool {
+		api := key.(*Api)
+		res[api.Method+" "+api.Route] = value.(*atomic.Int64).Load()
+		return true
+	})
+	return res
+}
+
+func (a *ApiGroup) deprecationHandler(api *Api) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d := api.Deprecated
+		if d == nil {
+			c.Next()
+			return
+		}
+		h := c.Writer.Header()
+		if d.Since != nil {
+			h.Set("Deprecation", fmt.Sprintf("@%d", d.Since.Unix()))
+		} else {
+			h.Set("Deprecation", "true")
+		}
+		if d.Sunset != nil {
+			h.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if d.Replacement != "" {
+			h.Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, d.replacementPath()))
+		}
+		for g := a; g != nil; g = g.parent {
+			v, _ := g.deprecatedCalls.LoadOrStore(api, new(atomic.Int64))
+			v.(*atomic.Int64).Add(1)
+		}
+		for g := a; g != nil; g = g.parent {
+			if g.deprecatedHook != nil {
+				g.deprecatedHook(c, api)
+				break
+			}
+		}
+		c.Next()
+	}
+}
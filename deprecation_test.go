@@ -0,0 +1,70 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type deprecatedResponse struct {
+	Name     string `json:"name"`
+	Nickname string `json:"nickname" deprecated:"true"`
+}
+
+func TestDeprecated(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	RegisterAPI(group, gine, "GET", "/v1/users", func(ctx *gin.Context, req *struct{}) *deprecatedResponse {
+		return &deprecatedResponse{}
+	}, WithTitle("list users v1"), WithDeprecated(since, sunset, "GET /v2/users"))
+	RegisterAPI(group, gine, "GET", "/v2/users", func(ctx *gin.Context, req *struct{}) *deprecatedResponse {
+		return &deprecatedResponse{}
+	}, WithTitle("list users"))
+
+	callers := []string{}
+	group.OnDeprecatedCall(func(c *gin.Context, api *Api) {
+		callers = append(callers, c.GetHeader("X-Client"))
+	})
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/v1/users", nil)
+		r.Header.Set("X-Client", "app")
+		gine.ServeHTTP(w, r)
+		h := w.Header()
+		if h.Get("Deprecation") != "@1704067200" || h.Get("Sunset") != "Sun, 30 Jun 2024 00:00:00 GMT" ||
+			h.Get("Link") != `</v2/users>; rel="successor-version"` {
+			t.Fatalf("unexpected deprecation headers: %v", h)
+		}
+	}
+	w := httptest.NewRecorder()
+	gine.ServeHTTP(w, httptest.NewRequest("GET", "/v2/users", nil))
+	if w.Header().Get("Deprecation") != "" {
+		t.Error("api is not deprecated")
+	}
+	if calls := group.DeprecatedCalls(); len(calls) != 1 || calls["GET /v1/users"] != 2 || len(callers) != 2 {
+		t.Errorf("unexpected deprecated calls: %v %v", calls, callers)
+	}
+
+	md := group.GenerateMarkdown()
+	for _, s := range []string{
+		"#### 0 ~~list users v1~~ **[已废弃]**",
+		"> 该接口已废弃，废弃时间: 2024-01-01，下线时间: 2024-06-30，请使用 [1 list users](#api-1)",
+		"|~~nickname~~ (已废弃)|string|",
+	} {
+		if !strings.Contains(md, s) {
+			t.Errorf("markdown should contain %q:\n%s", s, md)
+		}
+	}
+	if html := group.GenerateHtml(); !strings.Contains(html, `请使用 <a href="#api-1">1 list users</a>`) {
+		t.Error("html should link to replacement")
+	}
+	spec := group.GenerateOpenAPI("api", "1.0.0")
+	if op := spec.Paths["/v1/users"]["get"]; !op.Deprecated ||
+		!op.Responses["200"].Content["application/json"].Schema.Properties["nickname"].Deprecated {
+		t.Error("openapi should mark deprecated operation and field")
+	}
+}
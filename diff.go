@@ -0,0 +1,237 @@
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	ChangeAddApi      = "add_api"
+	ChangeRemoveApi   = "remove_api"
+	ChangeDescription = "description"
+	ChangeAddField    = "add_field"
+	ChangeRemoveField = "remove_field"
+	ChangeField       = "change_field"
+)
+
+const (
+	PartRequest  = "request"
+	PartResponse = "response"
+)
+
+// ApiChange is a difference of an api between two snapshots, Old and New are set for field changes
+type ApiChange struct {
+	Kind     string
+	Method   string
+	Route    string
+	Part     string
+	Field    string
+	Breaking bool
+	Message  string
+	Old      *FiledDoc
+	New      *FiledDoc
+}
+
+func (c *ApiChange) String() string {
+	s := c.Method + " " + c.Route
+	if c.Part != "" {
+		s += " " + c.Part
+	}
+	if c.Field != "" {
+		s += " '" + c.Field + "'"
+	}
+	s += ": " + c.Message
+	if c.Breaking {
+		s = "[breaking] " + s
+	}
+	return s
+}
+
+type ApiDiff struct {
+	Changes []*ApiChange
+}
+
+// Breaking returns the changes which may break existing clients
+func (d *ApiDiff) Breaking() []*ApiChange {
+	res := []*ApiChange{}
+	for _, c := range d.Changes {
+		if c.Breaking {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func (d *ApiDiff) HasBreaking() bool {
+	return len(d.Breaking()) > 0
+}
+
+func (d *ApiDiff) String() string {
+	ss := make([]string, len(d.Changes))
+	for i, c := range d.Changes {
+		ss[i] = c.String()
+	}
+	return strings.Join(ss, "\n")
+}
+
+// LoadApis loads apis from the json dumped by HandlerAllApiSchemas
+func LoadApis(data []byte) ([]*Api, error) {
+	apis := []*Api{}
+	if err := json.Unmarshal(data, &apis); err != nil {
+		return nil, fmt.Errorf("unmarshal api schemas error: %w", err)
+	}
+	return apis, nil
+}
+
+// DiffApiSchemas compares two json dumps of HandlerAllApiSchemas
+func DiffApiSchemas(old, new []byte) (*ApiDiff, error) {
+	oa, err := LoadApis(old)
+	if err != nil {
+		return nil, err
+	}
+	na, err := LoadApis(new)
+	if err != nil {
+		return nil, err
+	}
+	return DiffApis(oa, na), nil
+}
+
+func apiKey(a *Api) string {
+	return a.Method + " " + a.Route
+}
+
+// DiffApis compares two api sets, apis are matched by method and route, fields are compared with the flattening of Schema.Doc.
+// Removed apis, newly required request fields, type and location changes, removed request enum values and removed response fields are breaking.
+func DiffApis(old, new []*Api) *ApiDiff {
+	d := &ApiDiff{}
+	olds := map[string]*Api{}
+	for _, a := range old {
+		olds[apiKey(a)] = a
+	}
+	news := map[string]*Api{}
+	for _, a := range new {
+		news[apiKey(a)] = a
+	}
+	for _, a := range old {
+		if news[apiKey(a)] == nil {
+			d.add(&ApiChange{Kind: ChangeRemoveApi, Method: a.Method, Route: a.Route, Breaking: true, Message: "api is removed"})
+		}
+	}
+	for _, a := range new {
+		o := olds[apiKey(a)]
+		if o == nil {
+			d.add(&ApiChange{Kind: ChangeAddApi, Method: a.Method, Route: a.Route, Message: "api is added"})
+			continue
+		}
+		if o.Description != a.Description || o.Title != a.Title {
+			d.add(&ApiChange{Kind: ChangeDescription, Method: a.Method, Route: a.Route, Message: "title or description is changed"})
+		}
+		d.diffFields(a, PartRequest, o.RequestSchema, a.RequestSchema)
+		d.diffFields(a, PartResponse, o.ResponseSchema, a.ResponseSchema)
+	}
+	return d
+}
+
+func (d *ApiDiff) add(c *ApiChange) {
+	d.Changes = append(d.Changes, c)
+}
+
+func schemaDocs(s *Schema) []*FiledDoc {
+	if s == nil {
+		return nil
+	}
+	return s.Doc()
+}
+
+func (d *ApiDiff) diffFields(a *Api, part string, old, new *Schema) {
+	request := part == PartRequest
+	olds := map[string]*FiledDoc{}
+	for _, f := range schemaDocs(old) {
+		olds[f.Field] = f
+	}
+	news := map[string]*FiledDoc{}
+	for _, f := range schemaDocs(new) {
+		news[f.Field] = f
+	}
+	change := func(kind string, o, n *FiledDoc, breaking bool, format string, args ...any) {
+		f := n
+		if f == nil {
+			f = o
+		}
+		d.add(&ApiChange{Kind: kind, Method: a.Method, Route: a.Route, Part: part, Field: f.Field,
+			Breaking: breaking, Message: fmt.Sprintf(format, args...), Old: o, New: n})
+	}
+
+	required := func(f *FiledDoc) bool {
+		return f.Required || requiredByBinding(f.Binding)
+	}
+
+	for _, o := range schemaDocs(old) {
+		if news[o.Field] == nil {
+			change(ChangeRemoveField, o, nil, !request, "field is removed")
+		}
+	}
+	for _, n := range schemaDocs(new) {
+		o := olds[n.Field]
+		if o == nil {
+			if request && required(n) {
+				change(ChangeAddField, nil, n, true, "required field is added")
+			} else {
+				change(ChangeAddField, nil, n, false, "field is added")
+			}
+			continue
+		}
+		if o.Type != n.Type {
+			change(ChangeField, o, n, true, "type is changed from %s to %s", o.Type, n.Type)
+		}
+		if request && o.Location != n.Location {
+			change(ChangeField, o, n, true, "location is changed from %s to %s", o.Location, n.Location)
+		}
+		if request && !required(o) && required(n) {
+			change(ChangeField, o, n, true, "field becomes required")
+		}
+		if removed := removedEnums(o.Enum, n.Enum); len(removed) > 0 {
+			change(ChangeField, o, n, request, "enum values %s are removed", strings.Join(removed, ","))
+		}
+		if request && o.Enum == "" && n.Enum != "" {
+			change(ChangeField, o, n, true, "enum values are restricted to %s", n.Enum)
+		}
+		if added := removedEnums(n.Enum, o.Enum); len(added) > 0 {
+			change(ChangeField, o, n, false, "enum values %s are added", strings.Join(added, ","))
+		}
+		if request && required(o) && !required(n) {
+			change(ChangeField, o, n, false, "field becomes optional")
+		}
+		if o.Binding != n.Binding {
+			change(ChangeField, o, n, false, "binding is changed from '%s' to '%s'", o.Binding, n.Binding)
+		}
+		if o.Default != n.Default {
+			change(ChangeField, o, n, false, "default is changed from '%s' to '%s'", o.Default, n.Default)
+		}
+		if o.Description != n.Description {
+			change(ChangeField, o, n, false, "description is changed")
+		}
+	}
+}
+
+// removedEnums returns values of comma separated enum old which are not in new,
+// empty new enum means any value is accepted.
+func removedEnums(old, new string) []string {
+	if old == "" || new == "" {
+		return nil
+	}
+	values := map[string]bool{}
+	for _, v := range strings.Split(new, ",") {
+		values[v] = true
+	}
+	res := []string{}
+	for _, v := range strings.Split(old, ",") {
+		if !values[v] {
+			res = append(res, v)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,114 @@
+package swagger
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"testing"
+)
+
+type diffRequestV1 struct {
+	Id    int    `json:"id" location:"path,id"`
+	Kind  string `json:"kind" location:"query,kind" enum:"a,b,c"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type diffResponseV1 struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+type diffRequestV2 struct {
+	Id    string `json:"id" location:"path,id"`
+	Kind  string `json:"kind" location:"query,kind" enum:"a,b"`
+	Name  string `json:"name" location:"query,name"`
+	Phone string `json:"phone" required:"true"`
+}
+
+type diffResponseV2 struct {
+	Name string `json:"name" desc:"user name"`
+	Age  int    `json:"age"`
+}
+
+func TestDiffApis(t *testing.T) {
+	v1 := NewAPIGroup()
+	RegisterAPI(v1, gin.New(), "POST", "/users/:id", func(ctx *gin.Context, req *diffRequestV1) *diffResponseV1 { return nil })
+	RegisterAPI(v1, gin.New(), "DELETE", "/users/:id", func(ctx *gin.Context, req *diffRequestV1) *diffResponseV1 { return nil })
+	v2 := NewAPIGroup()
+	RegisterAPI(v2, gin.New(), "POST", "/users/:id", func(ctx *gin.Context, req *diffRequestV2) *diffResponseV2 { return nil })
+	RegisterAPI(v2, gin.New(), "GET", "/users", func(ctx *gin.Context, req *diffRequestV2) *diffResponseV2 { return nil })
+
+	old, _ := json.Marshal(v1.Apis())
+	diff, err := DiffApiSchemas(old, mustJson(v2.Apis()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expects := map[string]bool{
+		"[breaking] DELETE /users/:id: api is removed":                                      true,
+		"GET /users: api is added":                                                          true,
+		"[breaking] POST /users/:id request 'email': field is removed":                      false,
+		"POST /users/:id request 'email': field is removed":                                 true,
+		"[breaking] POST /users/:id request 'phone': required field is added":               true,
+		"[breaking] POST /users/:id request 'id': type is changed from integer to string":   true,
+		"[breaking] POST /users/:id request 'kind': enum values c are removed":              true,
+		"[breaking] POST /users/:id request 'name': location is changed from json to query": true,
+		"[breaking] POST /users/:id response 'score': field is removed":                     true,
+		"POST /users/:id response 'age': field is added":                                    true,
+		"POST /users/:id response 'name': description is changed":                           true,
+	}
+	got := map[string]bool{}
+	for _, c := range diff.Changes {
+		got[c.String()] = true
+	}
+	for s, expect := range expects {
+		if got[s] != expect {
+			t.Errorf("change %q: expect %v\n%s", s, expect, diff)
+		}
+	}
+	if len(diff.Changes) != 10 || len(diff.Breaking()) != 6 {
+		t.Errorf("unexpected changes:\n%s", diff)
+	}
+	if DiffApis(v1.Apis(), v1.Apis()).HasBreaking() {
+		t.Error("same apis should have no breaking change")
+	}
+}
+
+func mustJson(v any) []byte {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+func TestDiffBindingRequired(t *testing.T) {
+	v1 := NewAPIGroup()
+	RegisterAPI(v1, gin.New(), "POST", "/users", func(ctx *gin.Context, req *struct {
+		Name string `json:"name"`
+	}) *diffResponseV1 {
+		return nil
+	})
+	v2 := NewAPIGroup()
+	RegisterAPI(v2, gin.New(), "POST", "/users", func(ctx *gin.Context, req *struct {
+		Name  string `json:"name" binding:"required"`
+		Phone string `json:"phone" binding:"required,min=6"`
+	}) *diffResponseV1 {
+		return nil
+	})
+	diff, err := DiffApiSchemas(mustJson(v1.Apis()), mustJson(v2.Apis()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]bool{}
+	for _, c := range diff.Changes {
+		got[c.String()] = true
+	}
+	for _, s := range []string{
+		"[breaking] POST /users request 'phone': required field is added",
+		"[breaking] POST /users request 'name': field becomes required",
+	} {
+		if !got[s] {
+			t.Errorf("change %q is not reported:\n%s", s, diff)
+		}
+	}
+}
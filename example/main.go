@@ -72,6 +72,8 @@ func main() {
 	ginEngine.GET("/apidoc.html", apiGroup.HandlerDocumentHtml())
 	ginEngine.GET("/apidoc.md", apiGroup.HandlerDocumentMd())
 	ginEngine.GET("/apischema", apiGroup.HandlerAllApiSchemas())
+	ginEngine.GET("/postman.json", apiGroup.HandlerPostmanCollection())
+	ginEngine.GET("/swagger/*any", apiGroup.HandlerSwaggerUI("/swagger"))
 	ginEngine.Run(":8902")
 }
 
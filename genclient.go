@@ -0,0 +1,309 @@
+package swagger
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+//go:embed client_template.go.tmpl
+var goClientTlp string
+
+type clientParam struct {
+	Name  string
+	Field string
+	Type  string
+	Zero  string
+}
+
+type clientMethod struct {
+	Api     *Api
+	Name    string
+	ReqType string
+	ResType string
+	// PathExpr is the go expression building request path
+	PathExpr string
+	Query    []*clientParam
+	Header   []*clientParam
+	HasBody  bool
+}
+
+type clientType struct {
+	Name string
+	Doc  string
+	Def  string
+}
+
+type goClient struct {
+	Package string
+	Types   []*clientType
+	Methods []*clientMethod
+}
+
+// GenerateGoClient generates the go client of apis visible to the audience, public by default
+func (a *ApiGroup) GenerateGoClient(pkg string, audience ...Audience) ([]byte, error) {
+	return GenerateGoClient(filterApis(a.apis, audienceOf(audience)), pkg)
+}
+
+// GenerateGoClient generates a go package source with a Client that has one method per exported api.
+func GenerateGoClient(apis []*Api, pkg string) ([]byte, error) {
+	c := &goClient{Package: pkg}
+	names := map[string]int{}
+	for _, api := range apis {
+		if api.unexported {
+			continue
+		}
+		m := &clientMethod{
+			Api:  api,
+			Name: uniqueName(names, clientMethodName(api)),
+		}
+		m.ReqType = m.Name + "Request"
+		m.ResType = m.Name + "Response"
+
+		fieldNames := map[string]int{}
+		params := map[string]*clientParam{}
+		fields := []string{}
+		for _, p := range collectParams(api.RequestSchema) {
+			cp := &clientParam{
+				Name:  p.name,
+				Field: uniqueName(fieldNames, goExportedName(p.name)),
+				Type:  goTypeExpr(p.schema, false, 1),
+			}
+			cp.Zero = goZeroCheck(p.schema, "req."+cp.Field)
+			if cp.Zero == "" && p.schema.Location != "path" {
+				// the server only binds scalar query and header values
+				continue
+			}
+			params[p.name] = cp
+			fields = append(fields, goFieldDoc(p.schema, 1)+fmt.Sprintf("\t%s %s `json:\"-\" location:\"%s,%s\"`", cp.Field, cp.Type, p.schema.Location, p.name))
+			switch p.schema.Location {
+			case "query":
+				m.Query = append(m.Query, cp)
+			case "header":
+				m.Header = append(m.Header, cp)
+			}
+		}
+		for _, name := range sortedProperties(api.RequestSchema) {
+			sc := api.RequestSchema.Properties[name]
+			if !isBodyField(sc) {
+				continue
+			}
+			m.HasBody = true
+			fields = append(fields, goFieldDoc(sc, 1)+"\t"+goStructField(fieldNames, name, sc, true, 1))
+		}
+		exprs := []string{}
+		literal := ""
+		for _, seg := range strings.Split(api.Route, "/") {
+			if seg == "" {
+				continue
+			}
+			if seg[0] == ':' || seg[0] == '*' {
+				p := params[seg[1:]]
+				if p == nil {
+					// path param is not declared in request, fill it with an extra field
+					p = &clientParam{Name: seg[1:], Field: uniqueName(fieldNames, goExportedName(seg[1:])), Type: "string"}
+					fields = append(fields, fmt.Sprintf("\t%s string `json:\"-\" location:\"path,%s\"`", p.Field, p.Name))
+				}
+				if seg[0] == '*' {
+					exprs = append(exprs, fmt.Sprintf("%q", literal+"/"), fmt.Sprintf("strings.TrimPrefix(fmt.Sprint(req.%s), \"/\")", p.Field))
+				} else {
+					exprs = append(exprs, fmt.Sprintf("%q", literal+"/"), fmt.Sprintf("url.PathEscape(fmt.Sprint(req.%s))", p.Field))
+				}
+				literal = ""
+				continue
+			}
+			literal += "/" + seg
+		}
+		if literal != "" || len(exprs) == 0 {
+			exprs = append(exprs, fmt.Sprintf("%q", literal))
+		}
+		m.PathExpr = strings.Join(exprs, " + ")
+
+		c.Types = append(c.Types, &clientType{
+			Name: m.ReqType,
+			Doc:  api.Title,
+			Def:  "struct {\n" + strings.Join(fields, "\n") + "\n}",
+		}, &clientType{
+			Name: m.ResType,
+			Doc:  api.Title,
+			Def:  goTypeExpr(api.ResponseSchema, false, 0),
+		})
+		c.Methods = append(c.Methods, m)
+	}
+
+	t, err := template.New("client").Funcs(template.FuncMap{
+		"comment": goComment,
+	}).Parse(goClientTlp)
+	if err != nil {
+		return nil, err
+	}
+	bf := &bytes.Buffer{}
+	if err = t.Execute(bf, c); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(bf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated client error: %w", err)
+	}
+	return src, nil
+}
+
+type schemaParam struct {
+	name   string
+	schema *Schema
+}
+
+// collectParams returns path, query and header fields of request schema, nested ones included
+func collectParams(s *Schema) []*schemaParam {
+	res := []*schemaParam{}
+	var walk func(s *Schema)
+	walk = func(s *Schema) {
+		for _, name := range sortedProperties(s) {
+			p := s.Properties[name]
+			switch p.Location {
+			case "path", "query", "header":
+				res = append(res, &schemaParam{name: name, schema: p})
+				continue
+			}
+			walk(p)
+		}
+	}
+	walk(s)
+	sort.SliceStable(res, func(i, j int) bool {
+		return priority[res[i].schema.Location] > priority[res[j].schema.Location]
+	})
+	return res
+}
+
+func isBodyField(s *Schema) bool {
+	return s.Location == "" || s.Location == "json"
+}
+
+func sortedProperties(s *Schema) []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func clientMethodName(api *Api) string {
+	name := goExportedName(api.Title)
+	if name == "" {
+		name = goExportedName(strings.ToLower(api.Method) + "_" + strings.NewReplacer(":", "", "*", "").Replace(api.Route))
+	}
+	return name
+}
+
+// goExportedName converts names like "create_user", "x-ttl" or "get user" to CreateUser, XTtl, GetUser
+func goExportedName(s string) string {
+	sb := strings.Builder{}
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) || r > unicode.MaxASCII {
+			upper = true
+			continue
+		}
+		if sb.Len() == 0 && unicode.IsDigit(r) {
+			sb.WriteByte('N')
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func uniqueName(names map[string]int, name string) string {
+	names[name]++
+	if n := names[name]; n > 1 {
+		return fmt.Sprintf("%s%d", name, n)
+	}
+	return name
+}
+
+func goTypeExpr(s *Schema, body bool, indent int) string {
+	switch s.Type {
+	case "object":
+		if len(s.Properties) == 0 {
+			return "map[string]any"
+		}
+		names := map[string]int{}
+		sb := strings.Builder{}
+		sb.WriteString("struct {\n")
+		for _, name := range sortedProperties(s) {
+			p := s.Properties[name]
+			if body && !isBodyField(p) {
+				continue
+			}
+			sb.WriteString(goFieldDoc(p, indent+1))
+			sb.WriteString(strings.Repeat("\t", indent+1))
+			sb.WriteString(goStructField(names, name, p, body, indent+1))
+			sb.WriteString("\n")
+		}
+		sb.WriteString(strings.Repeat("\t", indent) + "}")
+		return sb.String()
+	case "array":
+		if s.Items == nil {
+			return "[]any"
+		}
+		return "[]" + goTypeExpr(s.Items, body, indent)
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "string":
+		return "string"
+	}
+	return "any"
+}
+
+func goStructField(names map[string]int, name string, s *Schema, body bool, indent int) string {
+	tag := name
+	if !s.Required {
+		tag += ",omitempty"
+	}
+	return fmt.Sprintf("%s %s `json:\"%s\"`", uniqueName(names, goExportedName(name)), goTypeExpr(s, body, indent), tag)
+}
+
+// goZeroCheck returns the condition of expr being set, empty for non scalar values
+func goZeroCheck(s *Schema, expr string) string {
+	switch s.Type {
+	case "string":
+		return expr + ` != ""`
+	case "integer", "number":
+		return expr + " != 0"
+	case "boolean":
+		return expr
+	}
+	return ""
+}
+
+func goFieldDoc(s *Schema, indent int) string {
+	doc := s.Description
+	if len(s.Enum) > 0 {
+		doc = strings.TrimSpace(doc + " enum: " + strings.Join(s.Enum, ","))
+	}
+	if doc == "" {
+		return ""
+	}
+	return goComment(doc, indent) + "\n"
+}
+
+func goComment(doc string, indent int) string {
+	lines := strings.Split(strings.TrimSpace(doc), "\n")
+	for i, l := range lines {
+		lines[i] = strings.Repeat("\t", indent) + "// " + l
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,66 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+func TestGenerateGoClient(t *testing.T) {
+	group := NewAPIGroup()
+	gine := gin.New()
+	RegisterAPI(group, gine, "POST", "/hello/:key/*path", HandlerReq, WithTitle("hello"))
+	RegisterAPI(group, gine, "GET", "/hello/:key", HandlerReq, WithTitle("hello"))
+
+	src, err := group.GenerateGoClient("helloclient")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"func (c *Client) Hello(", "func (c *Client) Hello2(", `query.Set("file"`, `url.PathEscape(fmt.Sprint(req.Key))`} {
+		if !strings.Contains(string(src), s) {
+			t.Fatalf("generated client does not contain %s:\n%s", s, src)
+		}
+	}
+
+	checkGoClient(t, src)
+}
+
+func TestGenerateGoClientStructParams(t *testing.T) {
+	group := NewAPIGroup()
+	RegisterAPI(group, gin.New(), "GET", "/items", func(ctx *gin.Context, req *struct {
+		Filter struct {
+			Name string `json:"name"`
+		} `json:"filter" location:"query,filter"`
+		Tags  []string `json:"tags" location:"header,X-Tags"`
+		Limit int      `json:"limit" location:"query,limit"`
+	}) *response {
+		return nil
+	}, WithTitle("list items"))
+
+	src, err := group.GenerateGoClient("itemclient")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(src), `query.Set("limit"`) || strings.Contains(string(src), "req.Filter") ||
+		strings.Contains(string(src), "req.Tags") {
+		t.Errorf("only scalar params should be sent:\n%s", src)
+	}
+	checkGoClient(t, src)
+}
+
+func checkGoClient(t *testing.T, src []byte) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "client.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err = conf.Check(f.Name.Name, fset, []*ast.File{f}, nil); err != nil {
+		t.Fatalf("generated client does not compile: %v\n%s", err, src)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	_ "embed"
 	"fmt"
+	"strconv"
 	"strings"
 	"text/template"
 )
@@ -14,15 +15,21 @@ var markdownTlp string
 //go:embed doc_template.html
 var htmlTlp string
 
-func GenerateMarkdown(api []*Api) string {
-	return generateFromTemplate(api, markdownTlp)
+func GenerateMarkdown(api []*Api, audience ...Audience) string {
+	return generateFromTemplate(filterApis(api, audienceOf(audience)), nil, markdownTlp)
 }
 
-func GenerateHtml(api []*Api) string {
-	return generateFromTemplate(api, htmlTlp)
+func GenerateHtml(api []*Api, audience ...Audience) string {
+	return generateFromTemplate(filterApis(api, audienceOf(audience)), nil, htmlTlp)
 }
 
-func generateFromTemplate(api []*Api, tlp string) string {
+func generateFromTemplate(api []*Api, webhooks []*Webhook, tlp string) string {
+	return generateGroupFromTemplate(nil, api, webhooks, tlp)
+}
+
+// generateGroupFromTemplate generates docs of apis registered in group g, sections follow sub-groups of g if any,
+// and docs of a version view list all versions in the version switcher
+func generateGroupFromTemplate(g *ApiGroup, api []*Api, webhooks []*Webhook, tlp string) string {
 
 	apidocs := []*apiDoc{}
 	for _, a := range api {
@@ -30,6 +37,7 @@ func generateFromTemplate(api []*Api, tlp string) string {
 			continue
 		}
 		apidocs = append(apidocs, &apiDoc{
+			Id:  strconv.Itoa(len(apidocs)),
 			Api: a,
 			Req: a.RequestSchema.Doc(),
 			Res: a.ResponseSchema.Doc(),
@@ -62,20 +70,176 @@ func generateFromTemplate(api []*Api, tlp string) string {
 			ReqHeaderExample: func() string {
 				return strings.Join(a.RequestSchema.genExampleHeader(), "\n")
 			}(),
+			Snippets:  a.Snippets(ExampleBaseUrl),
+			TryParams: a.tryParams(),
+		})
+	}
+	linkReplacements(apidocs)
+	hookdocs := []*webhookDoc{}
+	for _, w := range webhooks {
+		hookdocs = append(hookdocs, &webhookDoc{
+			Webhook:        w,
+			Payload:        w.PayloadSchema.Doc(),
+			PayloadExample: w.PayloadSchema.GenExampleJson(),
 		})
 	}
 	t, err := template.New("swagger").Parse(tlp)
 	if err != nil {
 		panic(err)
 	}
+	data := &docData{
+		Apis:     apidocs,
+		Tags:     groupByTags(apidocs),
+		Webhooks: hookdocs,
+	}
+	if g != nil {
+		data.Version, data.Versions = g.version, g.versions
+		if len(g.children) > 0 {
+			data.Tags = groupBySubGroups(g, apidocs)
+		}
+	}
 	bf := &bytes.Buffer{}
-	err = t.Execute(bf, apidocs)
+	err = t.Execute(bf, data)
 	if err != nil {
 		panic(err)
 	}
 	return bf.String()
 }
 
+type docData struct {
+	Apis     []*apiDoc
+	Tags     []*tagDoc
+	Webhooks []*webhookDoc
+	Version  string
+	Versions []string
+}
+
+// linkReplacements resolves replacements of deprecated apis by "METHOD route" or route
+func linkReplacements(apis []*apiDoc) {
+	index := map[string]*apiDoc{}
+	for _, a := range apis {
+		index[apiKey(a.Api)] = a
+		if index[a.Api.Route] == nil {
+			index[a.Api.Route] = a
+		}
+	}
+	for _, a := range apis {
+		if d := a.Api.Deprecated; d != nil && d.Replacement != "" {
+			a.Replacement = index[d.Replacement]
+		}
+	}
+}
+
+// DefaultTag groups apis without tags
+const DefaultTag = "default"
+
+// tagDoc lists apis having the tag, Primary are the apis whose first tag is it.
+// It is also a section of a sub-group, then Depth is the nesting level of the group.
+type tagDoc struct {
+	Name        string
+	Description string
+	Depth       int
+	Apis        []*apiDoc
+	Primary     []*apiDoc
+}
+
+// Indent returns the markdown list indent of the section
+func (t *tagDoc) Indent() string {
+	return strings.Repeat("  ", t.Depth)
+}
+
+// groupBySubGroups makes a section per group in depth first order, named by the path of group titles.
+// Apis of a group having more than one tag are grouped by tags in nested sections.
+func groupBySubGroups(g *ApiGroup, apis []*apiDoc) []*tagDoc {
+	sections := []*tagDoc{}
+	index := map[*ApiGroup]*tagDoc{}
+	var walk func(g *ApiGroup, name string, depth int)
+	walk = func(g *ApiGroup, name string, depth int) {
+		t := &tagDoc{Name: name, Description: g.description, Depth: depth}
+		index[g] = t
+		sections = append(sections, t)
+		for _, c := range g.children {
+			walk(c, strings.TrimPrefix(name+" / "+c.title, DefaultTag+" / "), depth+1)
+		}
+	}
+	name := g.title
+	if name == "" {
+		name = DefaultTag
+	}
+	walk(g, name, 0)
+	for _, a := range apis {
+		t := index[a.Api.group]
+		if t == nil {
+			// apis of the group itself or of a view of it
+			t = sections[0]
+		}
+		t.Apis = append(t.Apis, a)
+		t.Primary = append(t.Primary, a)
+	}
+	sections = nestTags(sections)
+	if len(sections[0].Apis) == 0 && sections[0].Description == "" {
+		sections = sections[1:]
+		for _, t := range sections {
+			t.Depth--
+		}
+	}
+	return sections
+}
+
+// nestTags moves apis of sections having more than one tag into tag sections following them
+func nestTags(sections []*tagDoc) []*tagDoc {
+	res := []*tagDoc{}
+	for _, s := range sections {
+		res = append(res, s)
+		tags := groupByTags(s.Primary)
+		if len(tags) <= 1 {
+			continue
+		}
+		s.Apis, s.Primary = nil, nil
+		for _, t := range tags {
+			t.Name = strings.TrimPrefix(s.Name+" / "+t.Name, DefaultTag+" / ")
+			t.Depth = s.Depth + 1
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// groupByTags groups apis by tags in the order of first appearance
+func groupByTags(apis []*apiDoc) []*tagDoc {
+	tags := []*tagDoc{}
+	index := map[string]*tagDoc{}
+	get := func(name string) *tagDoc {
+		t := index[name]
+		if t == nil {
+			t = &tagDoc{Name: name}
+			index[name] = t
+			tags = append(tags, t)
+		}
+		return t
+	}
+	for _, a := range apis {
+		names := a.Api.Tags
+		if len(names) == 0 {
+			names = []string{DefaultTag}
+		}
+		for i, name := range names {
+			t := get(name)
+			t.Apis = append(t.Apis, a)
+			if i == 0 {
+				t.Primary = append(t.Primary, a)
+			}
+		}
+	}
+	return tags
+}
+
+type webhookDoc struct {
+	Webhook        *Webhook
+	Payload        []*FiledDoc
+	PayloadExample string
+}
+
 type apiDoc struct {
 	Id                    string
 	Api                   *Api
@@ -84,6 +248,10 @@ type apiDoc struct {
 	ReqRequestLineExample string
 	ReqHeaderExample      string
 	ResExample            any
+	Snippets              []*Snippet
+	TryParams             []*tryParam
+	// Replacement is the documented successor of a deprecated api
+	Replacement *apiDoc
 
 	Req []*FiledDoc
 	Res []*FiledDoc
@@ -98,4 +266,40 @@ type FiledDoc struct {
 	Location    string
 	Default     string
 	Binding     string
+	Deprecated  bool
+}
+
+// tryParam is an editable path, query or header input of the try it out console
+type tryParam struct {
+	Name        string
+	Location    string
+	Value       string
+	Required    bool
+	Description string
+}
+
+func (a *Api) tryParams() []*tryParam {
+	res := []*tryParam{}
+	declared := map[string]bool{}
+	for _, p := range collectParams(a.RequestSchema) {
+		tp := &tryParam{
+			Name:        p.name,
+			Location:    p.schema.Location,
+			Required:    p.schema.Required || p.schema.Location == "path",
+			Description: p.schema.Description,
+		}
+		if p.schema.Location == "path" {
+			declared[p.name] = true
+			tp.Value = strings.TrimPrefix(a.RequestSchema.examplePathParam(p.name), "/")
+		} else if ex := p.schema.getExample(); ex != "-" {
+			tp.Value = ex
+		}
+		res = append(res, tp)
+	}
+	for _, param := range parsePathParams(a.Route) {
+		if !declared[param[1:]] {
+			res = append([]*tryParam{{Name: param[1:], Location: "path", Value: param[1:], Required: true}}, res...)
+		}
+	}
+	return res
 }
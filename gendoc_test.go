@@ -0,0 +1,39 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"strings"
+	"testing"
+)
+
+type tryItRequest struct {
+	Id    int    `json:"id" location:"path,id" example:"42"`
+	Kind  string `json:"kind" location:"query,kind" enum:"a,b" desc:"user kind"`
+	Trace string `json:"trace" location:"header,X-Trace" example:"t-1" binding:"required"`
+	Name  string `json:"name" example:"tom"`
+}
+
+func TestTryItOut(t *testing.T) {
+	group := NewAPIGroup()
+	RegisterAPI(group, gin.New(), "POST", "/users/:id/:tab", func(ctx *gin.Context, req *tryItRequest) *securityResponse {
+		return &securityResponse{}
+	}, WithTitle("update user"))
+
+	html := group.GenerateHtml()
+	for _, s := range []string{
+		`<details class="try" data-method="POST" data-route="/users/:id/:tab">`,
+		"<td>id *</td>\n                        <td>path</td>",
+		`<input class="try-input" data-name="id" data-location="path" value="42"/>`,
+		`<input class="try-input" data-name="tab" data-location="path" value="tab"/>`,
+		`<input class="try-input" data-name="kind" data-location="query" value="a"/>`,
+		`<input class="try-input" data-name="X-Trace" data-location="header" value="t-1"/>`,
+		`<textarea class="try-body" spellcheck="false">{` + "\n" + `   &#34;name&#34;: &#34;tom&#34;` + "\n" + `}</textarea>`,
+	} {
+		if !strings.Contains(html, s) {
+			t.Errorf("try it out console should contain %q", s)
+		}
+	}
+	if strings.Contains(html, `data-name="name"`) {
+		t.Error("body fields should not be try it out inputs")
+	}
+}
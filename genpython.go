@@ -0,0 +1,231 @@
+package swagger
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+//go:embed client_template.py.tmpl
+var pyClientTlp string
+
+type pyMethod struct {
+	Api     *Api
+	Name    string
+	ReqType string
+	ResType string
+	// PathExpr is the python expression building request path
+	PathExpr string
+	Query    []string
+	Header   []string
+	Body     []string
+}
+
+type pyClient struct {
+	Types   []*clientType
+	Methods []*pyMethod
+}
+
+// GeneratePython generates the python client of apis visible to the audience, public by default
+func (a *ApiGroup) GeneratePython(audience ...Audience) string {
+	return GeneratePython(filterApis(a.apis, audienceOf(audience)))
+}
+
+// GeneratePython generates a python module with TypedDicts of request and response schemas
+// and a urllib based Client with one method per exported api.
+func GeneratePython(apis []*Api) string {
+	c := &pyClient{}
+	names := map[string]int{}
+	for _, api := range apis {
+		if api.unexported {
+			continue
+		}
+		name := uniqueName(names, clientMethodName(api))
+		m := &pyMethod{
+			Api:     api,
+			Name:    pySnakeName(name),
+			ReqType: name + "Request",
+			ResType: name + "Response",
+		}
+		fields := []string{}
+		for _, p := range collectParams(api.RequestSchema) {
+			fields = append(fields, c.pyField(m.ReqType, p.name, p.schema, false))
+			switch p.schema.Location {
+			case "query":
+				m.Query = append(m.Query, p.name)
+			case "header":
+				m.Header = append(m.Header, p.name)
+			}
+		}
+		for _, name := range sortedProperties(api.RequestSchema) {
+			sc := api.RequestSchema.Properties[name]
+			if !isBodyField(sc) {
+				continue
+			}
+			m.Body = append(m.Body, name)
+			fields = append(fields, c.pyField(m.ReqType, name, sc, true))
+		}
+		c.Types = append(c.Types, &clientType{Name: m.ReqType, Doc: api.Title, Def: pyTypedDict(m.ReqType, fields)})
+
+		exprs := []string{}
+		literal := ""
+		for _, seg := range strings.Split(api.Route, "/") {
+			if seg == "" {
+				continue
+			}
+			if seg[0] == ':' || seg[0] == '*' {
+				exprs = append(exprs, pyString(literal+"/"), fmt.Sprintf("_path_param(req, %s, %v)", pyString(seg[1:]), pyBool(seg[0] == '*')))
+				literal = ""
+				continue
+			}
+			literal += "/" + seg
+		}
+		if literal != "" || len(exprs) == 0 {
+			exprs = append(exprs, pyString(literal))
+		}
+		m.PathExpr = strings.Join(exprs, " + ")
+
+		res := c.pyType(m.ResType, api.ResponseSchema, false)
+		if res == m.ResType {
+			c.Types[len(c.Types)-1].Doc = api.Title
+		} else {
+			c.Types = append(c.Types, &clientType{Name: m.ResType, Doc: api.Title, Def: m.ResType + " = " + res})
+		}
+		c.Methods = append(c.Methods, m)
+	}
+
+	t, err := template.New("python").Funcs(template.FuncMap{
+		"pystring": pyString,
+		"pylist": func(ss []string) string {
+			qs := make([]string, len(ss))
+			for i, s := range ss {
+				qs[i] = pyString(s)
+			}
+			return "[" + strings.Join(qs, ", ") + "]"
+		},
+		"pydoc": func(doc string) string {
+			doc = strings.ReplaceAll(strings.TrimSpace(doc), `"""`, `\"\"\"`)
+			return strings.ReplaceAll(doc, "\n", "\n        ")
+		},
+	}).Parse(pyClientTlp)
+	if err != nil {
+		panic(err)
+	}
+	bf := &bytes.Buffer{}
+	if err = t.Execute(bf, c); err != nil {
+		panic(err)
+	}
+	return bf.String()
+}
+
+// pyType returns the python type of s, object schemas are emitted as TypedDict named name
+func (c *pyClient) pyType(name string, s *Schema, body bool) string {
+	switch s.Type {
+	case "object":
+		if len(s.Properties) == 0 {
+			return "Dict[str, Any]"
+		}
+		fields := []string{}
+		for _, pn := range sortedProperties(s) {
+			p := s.Properties[pn]
+			if body && !isBodyField(p) {
+				continue
+			}
+			fields = append(fields, c.pyField(name, pn, p, body))
+		}
+		c.Types = append(c.Types, &clientType{Name: name, Def: pyTypedDict(name, fields)})
+		return name
+	case "array":
+		if s.Items == nil {
+			return "List[Any]"
+		}
+		return "List[" + c.pyType(name+"Item", s.Items, body) + "]"
+	case "integer", "number", "string":
+		if len(s.Enum) > 0 {
+			enums := make([]string, len(s.Enum))
+			for i, e := range s.Enum {
+				if s.Type == "string" {
+					enums[i] = pyString(e)
+				} else {
+					enums[i] = e
+				}
+			}
+			return "Literal[" + strings.Join(enums, ", ") + "]"
+		}
+		return map[string]string{"integer": "int", "number": "float", "string": "str"}[s.Type]
+	case "boolean":
+		return "bool"
+	}
+	return "Any"
+}
+
+func (c *pyClient) pyField(parent string, name string, s *Schema, body bool) string {
+	typ := c.pyType(parent+goExportedName(name), s, body)
+	if s.Required || s.Location == "path" {
+		typ = "Required[" + typ + "]"
+	}
+	doc := s.Description
+	if s.Location != "" && s.Location != "json" {
+		doc = strings.TrimSpace(doc + "\nlocation: " + s.Location)
+	}
+	if s.Default != "" {
+		doc = strings.TrimSpace(doc + "\ndefault: " + s.Default)
+	}
+	res := fmt.Sprintf("    %s: %s,", pyString(name), typ)
+	if doc != "" {
+		lines := strings.Split(doc, "\n")
+		for i, l := range lines {
+			lines[i] = "    # " + l
+		}
+		res = strings.Join(lines, "\n") + "\n" + res
+	}
+	return res
+}
+
+func pyTypedDict(name string, fields []string) string {
+	if len(fields) == 0 {
+		return fmt.Sprintf("%s = TypedDict(%s, {}, total=False)", name, pyString(name))
+	}
+	return fmt.Sprintf("%s = TypedDict(%s, {\n%s\n}, total=False)", name, pyString(name), strings.Join(fields, "\n"))
+}
+
+var pyKeywords = map[string]bool{
+	"and": true, "as": true, "assert": true, "async": true, "await": true, "break": true, "class": true,
+	"continue": true, "def": true, "del": true, "elif": true, "else": true, "except": true, "finally": true,
+	"for": true, "from": true, "global": true, "if": true, "import": true, "in": true, "is": true,
+	"lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true, "raise": true, "return": true,
+	"try": true, "while": true, "with": true, "yield": true, "None": true, "True": true, "False": true,
+}
+
+// pySnakeName converts CreateUser to create_user
+func pySnakeName(s string) string {
+	sb := strings.Builder{}
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	name := sb.String()
+	if pyKeywords[name] {
+		name += "_"
+	}
+	return name
+}
+
+func pyString(s string) string {
+	return fmt.Sprintf("%q", s)
+}
+
+func pyBool(b bool) string {
+	if b {
+		return "True"
+	}
+	return "False"
+}
@@ -0,0 +1,25 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"strings"
+	"testing"
+)
+
+func TestGeneratePython(t *testing.T) {
+	group := NewAPIGroup()
+	RegisterAPI(group, gin.New(), "POST", "/hello/:key/*path", HandlerReq, WithTitle("import"), WithDescription("say hello"))
+
+	py := group.GeneratePython()
+	for _, s := range []string{
+		`ImportRequest = TypedDict("ImportRequest", {`,
+		`"mod": Literal["read", "write"],`,
+		"    # username\n    \"name\": Required[str],",
+		"def import_(self, req: ImportRequest) -> ImportResponse:",
+		`_path_param(req, "path", True)`,
+	} {
+		if !strings.Contains(py, s) {
+			t.Fatalf("generated python does not contain %s:\n%s", s, py)
+		}
+	}
+}
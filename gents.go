@@ -0,0 +1,218 @@
+package swagger
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed client_template.ts.tmpl
+var tsClientTlp string
+
+type tsMethod struct {
+	Api     *Api
+	Name    string
+	ReqType string
+	ResType string
+	// PathExpr is the ts template literal building request path
+	PathExpr string
+	Query    []string
+	Header   []string
+	Body     []string
+}
+
+type tsClient struct {
+	Types   []*clientType
+	Methods []*tsMethod
+}
+
+// GenerateTypeScript generates the typescript client of apis visible to the audience, public by default
+func (a *ApiGroup) GenerateTypeScript(audience ...Audience) string {
+	return GenerateTypeScript(filterApis(a.apis, audienceOf(audience)))
+}
+
+// HandlerTypeScript serves the generated typescript client of the audience resolved by SetAudienceResolver as a .ts file
+func (a *ApiGroup) HandlerTypeScript() gin.HandlerFunc {
+	clients := map[Audience]string{}
+	for _, aud := range audiences {
+		clients[aud] = a.GenerateTypeScript(aud)
+	}
+	return func(c *gin.Context) {
+		ts := clients[a.audience(c)]
+		c.Writer.Header().Set("Content-Type", "application/typescript; charset=utf-8")
+		c.Writer.Header().Set("Content-Disposition", `inline; filename="api.ts"`)
+		c.Writer.WriteHeader(200)
+		c.Writer.WriteString(ts)
+	}
+}
+
+// GenerateTypeScript generates typescript interfaces of request and response schemas
+// and a fetch based client with one function per exported api.
+func GenerateTypeScript(apis []*Api) string {
+	c := &tsClient{}
+	names := map[string]int{}
+	for _, api := range apis {
+		if api.unexported {
+			continue
+		}
+		name := uniqueName(names, clientMethodName(api))
+		m := &tsMethod{
+			Api:     api,
+			Name:    strings.ToLower(name[:1]) + name[1:],
+			ReqType: name + "Request",
+			ResType: name + "Response",
+		}
+		fields := []string{}
+		for _, p := range collectParams(api.RequestSchema) {
+			fields = append(fields, tsField(p.name, p.schema, false, 1))
+			switch p.schema.Location {
+			case "query":
+				m.Query = append(m.Query, p.name)
+			case "header":
+				m.Header = append(m.Header, p.name)
+			}
+		}
+		for _, name := range sortedProperties(api.RequestSchema) {
+			sc := api.RequestSchema.Properties[name]
+			if !isBodyField(sc) {
+				continue
+			}
+			m.Body = append(m.Body, name)
+			fields = append(fields, tsField(name, sc, true, 1))
+		}
+		m.PathExpr = "`" + tsPathRe.ReplaceAllStringFunc(api.Route, func(s string) string {
+			if s[0] == '*' {
+				return "${String(req" + tsAccessor(s[1:]) + ` ?? "").replace(/^\//, "")}`
+			}
+			return "${encodeURIComponent(String(req" + tsAccessor(s[1:]) + "))}"
+		}) + "`"
+
+		def := "{}"
+		if len(fields) > 0 {
+			def = "{\n" + strings.Join(fields, "\n") + "\n}"
+		}
+		c.Types = append(c.Types, &clientType{
+			Name: m.ReqType,
+			Doc:  api.Title,
+			Def:  def,
+		}, &clientType{
+			Name: m.ResType,
+			Doc:  api.Title,
+			Def:  tsTypeExpr(api.ResponseSchema, false, 0),
+		})
+		c.Methods = append(c.Methods, m)
+	}
+
+	t, err := template.New("ts").Funcs(template.FuncMap{
+		"tsdoc":    tsDoc,
+		"tsstring": tsString,
+		"isobject": func(def string) bool { return strings.HasPrefix(def, "{") },
+	}).Parse(tsClientTlp)
+	if err != nil {
+		panic(err)
+	}
+	bf := &bytes.Buffer{}
+	if err = t.Execute(bf, c); err != nil {
+		panic(err)
+	}
+	return bf.String()
+}
+
+var (
+	tsPathRe  = regexp.MustCompile(`[:*][^/]+`)
+	tsIdentRe = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+)
+
+func tsTypeExpr(s *Schema, body bool, indent int) string {
+	switch s.Type {
+	case "object":
+		if len(s.Properties) == 0 {
+			return "Record<string, any>"
+		}
+		fields := []string{}
+		for _, name := range sortedProperties(s) {
+			p := s.Properties[name]
+			if body && !isBodyField(p) {
+				continue
+			}
+			fields = append(fields, tsField(name, p, body, indent+1))
+		}
+		return "{\n" + strings.Join(fields, "\n") + "\n" + strings.Repeat("  ", indent) + "}"
+	case "array":
+		if s.Items == nil {
+			return "Array<any>"
+		}
+		return "Array<" + tsTypeExpr(s.Items, body, indent) + ">"
+	case "integer", "number":
+		if len(s.Enum) > 0 {
+			return strings.Join(s.Enum, " | ")
+		}
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "string":
+		if len(s.Enum) > 0 {
+			enums := make([]string, len(s.Enum))
+			for i, e := range s.Enum {
+				enums[i] = tsString(e)
+			}
+			return strings.Join(enums, " | ")
+		}
+		return "string"
+	}
+	return "any"
+}
+
+func tsField(name string, s *Schema, body bool, indent int) string {
+	opt := "?"
+	if s.Required || s.Location == "path" {
+		opt = ""
+	}
+	key := name
+	if !tsIdentRe.MatchString(name) {
+		key = tsString(name)
+	}
+	doc := s.Description
+	if s.Location != "" && s.Location != "json" {
+		doc = strings.TrimSpace(doc + "\n@location " + s.Location)
+	}
+	if s.Default != "" {
+		doc = strings.TrimSpace(doc + "\n@default " + s.Default)
+	}
+	ind := strings.Repeat("  ", indent)
+	res := fmt.Sprintf("%s%s%s: %s;", ind, key, opt, tsTypeExpr(s, body, indent))
+	if doc != "" {
+		res = tsDoc(doc, indent) + "\n" + res
+	}
+	return res
+}
+
+func tsAccessor(name string) string {
+	if tsIdentRe.MatchString(name) {
+		return "." + name
+	}
+	return "[" + tsString(name) + "]"
+}
+
+func tsString(s string) string {
+	return fmt.Sprintf("%q", s)
+}
+
+func tsDoc(doc string, indent int) string {
+	ind := strings.Repeat("  ", indent)
+	lines := strings.Split(strings.TrimSpace(doc), "\n")
+	if len(lines) == 1 {
+		return ind + "/** " + strings.ReplaceAll(lines[0], "*/", "* /") + " */"
+	}
+	sb := strings.Builder{}
+	sb.WriteString(ind + "/**\n")
+	for _, l := range lines {
+		sb.WriteString(ind + " * " + strings.ReplaceAll(l, "*/", "* /") + "\n")
+	}
+	sb.WriteString(ind + " */")
+	return sb.String()
+}
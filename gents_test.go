@@ -0,0 +1,30 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	group := NewAPIGroup()
+	gine := gin.New()
+	RegisterAPI(group, gine, "POST", "/hello/:key/*path", HandlerReq, WithTitle("hello"), WithDescription("say hello"))
+	gine.GET("/api.ts", group.HandlerTypeScript())
+
+	w := httptest.NewRecorder()
+	gine.ServeHTTP(w, httptest.NewRequest("GET", "/api.ts", nil))
+	ts := w.Body.String()
+	for _, s := range []string{
+		"export interface HelloRequest {",
+		`mod?: "read" | "write";`,
+		"/** username */\n  name: string;",
+		"hello: (req: HelloRequest): Promise<HelloResponse> =>",
+		"encodeURIComponent(String(req.key))",
+	} {
+		if !strings.Contains(ts, s) {
+			t.Fatalf("generated typescript does not contain %s:\n%s", s, ts)
+		}
+	}
+}
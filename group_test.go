@@ -0,0 +1,213 @@
+package swagger
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type groupRequest struct {
+	Id int `json:"id" binding:"required"`
+}
+
+func TestGroup(t *testing.T) {
+	gine := gin.New()
+	root := NewAPIGroup()
+	trace := []string{}
+	bearer := BearerAuth("bearerAuth", func(c *gin.Context, token string) (any, error) {
+		if token != "t1" {
+			return nil, errors.New("invalid token")
+		}
+		return token, nil
+	})
+	users := root.Group(gine.Group("/users"),
+		WithGroupTitle("用户"), WithGroupDescription("用户管理接口"), WithGroupTags("user"),
+		WithGroupSecurity(bearer), WithGroupCommonParams[tenantParams](),
+		WithGroupErrHandler(func(c *gin.Context, err error) {
+			abortWithStatusJson(c, 422, &ErrorResponse{Error: err.Error()})
+		}),
+		WithGroupMiddleware(func(c *gin.Context) {
+			trace = append(trace, "users")
+		}))
+	admin := users.Group(users.Router().Group("/admin"), WithGroupTitle("管理员"),
+		WithGroupMiddleware(func(c *gin.Context) {
+			trace = append(trace, "admin")
+		}))
+	public := root.Group(gine.Group("/public"), WithGroupSecurity())
+
+	handler := func(ctx *gin.Context, req *groupRequest) *tenantResponse {
+		return &tenantResponse{Tenant: CommonParams[tenantParams](ctx).Tenant}
+	}
+	RegisterAPI(users, users.Router(), "POST", "/get", handler, WithTitle("get user"))
+	RegisterAPI(admin, admin.Router(), "POST", "/ban", handler, WithTitle("ban user"), WithTags("admin"))
+	RegisterAPI(public, public.Router(), "GET", "/ping", func(ctx *gin.Context, req *struct{}) *securityResponse {
+		return &securityResponse{}
+	}, WithTitle("ping"))
+
+	do := func(url, body string) (int, string) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", url, strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer t1")
+		r.Header.Set("X-Tenant", "acme")
+		gine.ServeHTTP(w, r)
+		return w.Code, w.Body.String()
+	}
+	if code, body := do("/users/admin/ban", `{"id":1}`); code != 200 || !strings.Contains(body, "acme") {
+		t.Errorf("unexpected admin response: %d %s", code, body)
+	}
+	if strings.Join(trace, ",") != "users,admin" {
+		t.Errorf("middlewares should be inherited in order: %v", trace)
+	}
+	if code, _ := do("/users/admin/ban", `{}`); code != 422 {
+		t.Errorf("error handler should be inherited, got %d", code)
+	}
+	w := httptest.NewRecorder()
+	gine.ServeHTTP(w, httptest.NewRequest("GET", "/public/ping", nil))
+	if w.Code != 200 {
+		t.Errorf("security override should make ping public, got %d", w.Code)
+	}
+
+	if apis := root.Apis(); len(apis) != 3 || len(users.Apis()) != 2 || len(admin.Apis()) != 1 {
+		t.Errorf("apis should be collected by parents: %d %d %d", len(apis), len(users.Apis()), len(admin.Apis()))
+	}
+	ban := admin.Apis()[0]
+	if ban.Tags[0] != "admin" || len(ban.Security) != 1 || ban.RequestSchema.Properties["X-Tenant"] == nil {
+		t.Errorf("unexpected inherited settings: %v %v", ban.Tags, ban.Security)
+	}
+
+	root.SetMock(true)
+	if code, body := do("/users/get", `{"id":1}`); code != 200 || strings.Contains(body, "acme") {
+		t.Errorf("mock switch of root should apply to sub-groups: %d %s", code, body)
+	}
+	root.SetMock(false)
+
+	md := root.GenerateMarkdown()
+	for _, s := range []string{
+		"- 用户\n  - [0 get user](#api-0)\n  - 用户 / 管理员\n    - [1 ban user](#api-1)\n- /public",
+		"### 用户\n\n用户管理接口\n",
+		"### 用户 / 管理员",
+	} {
+		if !strings.Contains(md, s) {
+			t.Errorf("markdown should contain %q:\n%s", s, md)
+		}
+	}
+	if html := root.GenerateHtml(); !strings.Contains(html, `<details class="nav-group depth-1" open>`) ||
+		!strings.Contains(html, `<div class="section-desc">用户管理接口</div>`) {
+		t.Error("html should show the group hierarchy")
+	}
+}
+
+func TestGroupAuthorizer(t *testing.T) {
+	gine := gin.New()
+	root := NewAPIGroup()
+	child := root.Group(gine.Group("/admin"))
+	RegisterAPI(child, child.Router(), "GET", "/stats", func(ctx *gin.Context, req *struct{}) *securityResponse {
+		return &securityResponse{User: "secret"}
+	}, WithPermissions("admin"))
+	root.SetAuthorizer(AuthorizerFunc(func(c *gin.Context, api *Api, principal any) error {
+		if c.GetHeader("X-Role") != "admin" {
+			return errors.New("admin only")
+		}
+		return nil
+	}))
+	call := func(role string) int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/admin/stats", nil)
+		r.Header.Set("X-Role", role)
+		gine.ServeHTTP(w, r)
+		return w.Code
+	}
+	if code := call("guest"); code != 403 {
+		t.Errorf("authorizer set on root later should apply to sub-group, got %d", code)
+	}
+	if code := call("admin"); code != 200 {
+		t.Errorf("admin should be allowed, got %d", code)
+	}
+}
+
+func TestGroupRuntimeSwitches(t *testing.T) {
+	gine := gin.New()
+	root := NewAPIGroup()
+	child := root.Group(gine.Group("/child"))
+	other := root.Group(gine.Group("/other"))
+	calls := 0
+	handler := func(ctx *gin.Context, req *struct{}) *versionResponse {
+		calls++
+		return &versionResponse{Version: "real"}
+	}
+	RegisterAPI(child, child.Router(), "GET", "/old", handler, WithDeprecated(time.Time{}, time.Time{}, ""))
+	RegisterAPI(other, other.Router(), "GET", "/old", handler, WithDeprecated(time.Time{}, time.Time{}, ""))
+	get := func(url string) {
+		w := httptest.NewRecorder()
+		gine.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+	}
+
+	child.SetMock(true)
+	get("/child/old")
+	get("/other/old")
+	if calls != 1 {
+		t.Errorf("only the child should be mocked, handler calls: %d", calls)
+	}
+	root.SetMock(true)
+	child.SetMock(false)
+	get("/child/old")
+	get("/other/old")
+	if calls != 2 {
+		t.Errorf("child should override root mock mode, handler calls: %d", calls)
+	}
+	root.SetMock(false)
+
+	hooked := []string{}
+	child.OnDeprecatedCall(func(c *gin.Context, api *Api) {
+		hooked = append(hooked, api.Route)
+	})
+	get("/child/old")
+	get("/other/old")
+	if len(hooked) != 1 || hooked[0] != "/child/old" {
+		t.Errorf("child hook should fire for child apis only: %v", hooked)
+	}
+	if calls := child.DeprecatedCalls(); len(calls) != 1 || calls["GET /child/old"] != 3 {
+		t.Errorf("unexpected child deprecated calls: %v", calls)
+	}
+	if calls := root.DeprecatedCalls(); len(calls) != 2 {
+		t.Errorf("root should count calls of sub-groups: %v", calls)
+	}
+
+	child.SetResponseValidation(ResponseValidateCount)
+	get("/child/old")
+	get("/other/old")
+	if v := child.ResponseViolations(); len(v) != 0 {
+		t.Errorf("valid responses should not be counted: %v", v)
+	}
+	if child.responseValidateMode() != ResponseValidateCount || other.responseValidateMode() != ResponseValidateOff {
+		t.Error("response validation should be set per group")
+	}
+}
+
+func TestGroupTags(t *testing.T) {
+	gine := gin.New()
+	root := NewAPIGroup()
+	users := root.Group(gine.Group("/users"), WithGroupTitle("users"))
+	handler := func(ctx *gin.Context, req *struct{}) *securityResponse {
+		return &securityResponse{}
+	}
+	RegisterAPI(root, gine, "GET", "/a", handler, WithTitle("get a"), WithTags("alpha"))
+	RegisterAPI(root, gine, "GET", "/b", handler, WithTitle("get b"), WithTags("beta"))
+	RegisterAPI(users, users.Router(), "GET", "/list", handler, WithTitle("list users"), WithTags("read"))
+	RegisterAPI(users, users.Router(), "POST", "/create", handler, WithTitle("create user"), WithTags("write"))
+
+	md := root.GenerateMarkdown()
+	for _, s := range []string{
+		"- alpha\n  - [0 get a](#api-0)\n- beta\n  - [1 get b](#api-1)\n- users\n" +
+			"  - users / read\n    - [2 list users](#api-2)\n  - users / write\n    - [3 create user](#api-3)\n",
+		"### alpha",
+		"### users / write",
+	} {
+		if !strings.Contains(md, s) {
+			t.Errorf("markdown should group apis by tags inside groups %q:\n%s", s, md)
+		}
+	}
+}
@@ -1,15 +1,23 @@
 package swagger
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"reflect"
 	"strings"
+	"sync"
 )
 
 type Handler[Req, Resp any] func(ctx *gin.Context, req *Req) *Resp
 
 type ErrHandler func(ctx *gin.Context, err error)
 
+// ErrorResponse is the body written by defaultErrHandler
+type ErrorResponse struct {
+	Error string `json:"error" desc:"error message"`
+}
+
 func defaultErrHandler(ctx *gin.Context, err error) {
 	ss := []string{}
 	es, ok := err.(validator.ValidationErrors)
@@ -25,9 +33,27 @@ func defaultErrHandler(ctx *gin.Context, err error) {
 	} else {
 		errmsg = err.Error()
 	}
-	abortWithStatusJson(ctx, 400, gin.H{"error": errmsg})
+	abortWithStatusJson(ctx, 400, &ErrorResponse{Error: errmsg})
 }
+
+// WrapHandler adapts hd to a gin handler for routes registered outside the group. Responses are validated
+// when response validation of the group is enabled, violations are counted by the first route serving it.
 func WrapHandler[Req, Resp any](a *ApiGroup, hd Handler[Req, Resp], errHandler ErrHandler) gin.HandlerFunc {
+	handler := wrapHandler(a, hd, errHandler, nil)
+	api := &Api{ResponseSchema: generateSchema(reflect.ValueOf(new(Resp)), "")}
+	var once sync.Once
+	return func(ctx *gin.Context) {
+		once.Do(func() {
+			api.Method, api.Route = ctx.Request.Method, ctx.FullPath()
+		})
+		a.validateResponse(ctx, api, func() {
+			handler(ctx)
+		})
+	}
+}
+
+// wrapHandler binds the request and runs it through interceptors around hd
+func wrapHandler[Req, Resp any](a *ApiGroup, hd Handler[Req, Resp], errHandler ErrHandler, interceptors []Interceptor[Req, Resp]) gin.HandlerFunc {
 	if errHandler == nil {
 		errHandler = defaultErrHandler
 	}
@@ -43,10 +69,20 @@ func WrapHandler[Req, Resp any](a *ApiGroup, hd Handler[Req, Resp], errHandler E
 			errHandler(ctx, err)
 			return
 		}
-		res := hd(ctx, req)
+		var res *Resp
+		if len(interceptors) == 0 {
+			res = hd(ctx, req)
+		} else {
+			chain := &HandlerChain[Req, Resp]{interceptors: interceptors, handler: hd}
+			res, err = chain.Next(ctx, req)
+		}
 		if ctx.IsAborted() {
 			return
 		}
+		if err != nil {
+			errHandler(ctx, err)
+			return
+		}
 
 		abortWithStatusJson(ctx, 200, res)
 	}
@@ -62,7 +98,95 @@ func abortWithStatusJson(ctx *gin.Context, statusCode int, msg any) {
 	ctx.Abort()
 }
 
+// Interceptor runs after bindRequest around the typed handler of an api. It may observe or replace
+// the request passed to chain.Next and the response and error returned, or return without calling
+// chain.Next to short-circuit, e.g. with a cached response. A non nil error is written by the error handler.
+type Interceptor[Req, Resp any] func(ctx *gin.Context, req *Req, chain *HandlerChain[Req, Resp]) (*Resp, error)
+
+// HandlerChain is the rest of interceptors and the handler of a request
 type HandlerChain[Req, Resp any] struct {
-	handlers []Handler[Req, Resp]
-	idx      int
+	interceptors []Interceptor[Req, Resp]
+	handler      Handler[Req, Resp]
+	idx          int
+}
+
+// Next runs the next interceptor, or the handler after the last one
+func (h *HandlerChain[Req, Resp]) Next(ctx *gin.Context, req *Req) (*Resp, error) {
+	if h.idx < len(h.interceptors) {
+		it := h.interceptors[h.idx]
+		h.idx++
+		return it(ctx, req, h)
+	}
+	return h.handler(ctx, req), nil
+}
+
+// GroupInterceptor intercepts every api of a group, req and the response are *Req and *Resp of the api,
+// a response of another type is passed to the error handler of the api as an error
+type GroupInterceptor func(ctx *gin.Context, req any, next func(req any) (any, error)) (any, error)
+
+// WithInterceptors adds interceptors to an api registered by RegisterAPI with handler of the same types,
+// they run after the interceptors of the group. RegisterGin and RegisterAllApi reject it, use Intercept instead.
+func WithInterceptors[Req, Resp any](interceptors ...Interceptor[Req, Resp]) OptFunc {
+	return func(o *Api) {
+		its, _ := o.interceptors.([]Interceptor[Req, Resp])
+		o.interceptors = append(its, interceptors...)
+	}
+}
+
+// Intercept adds interceptors to apis registered later, sub-groups created later inherit them
+func (a *ApiGroup) Intercept(interceptors ...GroupInterceptor) {
+	a.interceptors = append(a.interceptors, interceptors...)
+}
+
+// WithGroupInterceptors appends interceptors to the ones inherited from the parent group
+func WithGroupInterceptors(interceptors ...GroupInterceptor) GroupOptFunc {
+	return func(g *ApiGroup) {
+		g.Intercept(interceptors...)
+	}
+}
+
+// typedInterceptors returns the interceptors of group and api for handler of Req and Resp
+func typedInterceptors[Req, Resp any](a *ApiGroup, api *Api) []Interceptor[Req, Resp] {
+	its := []Interceptor[Req, Resp]{}
+	for _, gi := range a.interceptors {
+		its = append(its, adaptInterceptor[Req, Resp](gi))
+	}
+	if api.interceptors != nil {
+		ai, ok := api.interceptors.([]Interceptor[Req, Resp])
+		if !ok {
+			panic(fmt.Sprintf("interceptors of %s %s should be %T", api.Method, api.Title, ai))
+		}
+		its = append(its, ai...)
+	}
+	return its
+}
+
+func adaptInterceptor[Req, Resp any](gi GroupInterceptor) Interceptor[Req, Resp] {
+	return func(ctx *gin.Context, req *Req, chain *HandlerChain[Req, Resp]) (*Resp, error) {
+		res, err := gi(ctx, req, func(r any) (any, error) {
+			typed, ok := r.(*Req)
+			if !ok {
+				return nil, fmt.Errorf("interceptor replaced request with %T", r)
+			}
+			return chain.Next(ctx, typed)
+		})
+		if err != nil || res == nil {
+			return nil, err
+		}
+		resp, ok := res.(*Resp)
+		if !ok {
+			return nil, fmt.Errorf("interceptor returned %T, expect %T", res, resp)
+		}
+		return resp, nil
+	}
+}
+
+// runInterceptors runs group interceptors around handler for apis registered without types
+func runInterceptors(ctx *gin.Context, interceptors []GroupInterceptor, req any, handler func(req any) (any, error)) (any, error) {
+	if len(interceptors) == 0 {
+		return handler(req)
+	}
+	return interceptors[0](ctx, req, func(r any) (any, error) {
+		return runInterceptors(ctx, interceptors[1:], r, handler)
+	})
 }
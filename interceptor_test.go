@@ -0,0 +1,100 @@
+package swagger
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type interceptRequest struct {
+	Name string `json:"name"`
+}
+
+type interceptResponse struct {
+	Greeting string `json:"greeting"`
+	Server   string `json:"server"`
+}
+
+func TestInterceptors(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	audit := []string{}
+	group.Intercept(func(ctx *gin.Context, req any, next func(req any) (any, error)) (any, error) {
+		audit = append(audit, req.(*interceptRequest).Name)
+		return next(req)
+	})
+	cache := map[string]*interceptResponse{"cached": {Greeting: "from cache"}}
+	calls := 0
+	RegisterAPI(group, gine, "POST", "/hello", func(ctx *gin.Context, req *interceptRequest) *interceptResponse {
+		calls++
+		return &interceptResponse{Greeting: "hello " + req.Name}
+	}, WithInterceptors[interceptRequest, interceptResponse](
+		func(ctx *gin.Context, req *interceptRequest, chain *HandlerChain[interceptRequest, interceptResponse]) (*interceptResponse, error) {
+			if res, ok := cache[req.Name]; ok {
+				return res, nil
+			}
+			if req.Name == "" {
+				return nil, errors.New("name is empty")
+			}
+			res, err := chain.Next(ctx, &interceptRequest{Name: strings.ToUpper(req.Name)})
+			if res != nil {
+				res.Server = "s1"
+			}
+			return res, err
+		}))
+
+	do := func(body string) (int, string) {
+		w := httptest.NewRecorder()
+		gine.ServeHTTP(w, httptest.NewRequest("POST", "/hello", strings.NewReader(body)))
+		return w.Code, w.Body.String()
+	}
+	if code, body := do(`{"name":"bob"}`); code != 200 || body != `{"greeting":"hello BOB","server":"s1"}` {
+		t.Errorf("interceptor should replace request and enrich response: %d %s", code, body)
+	}
+	if code, body := do(`{"name":"cached"}`); code != 200 || !strings.Contains(body, "from cache") || calls != 1 {
+		t.Errorf("interceptor should short-circuit: %d %s %d", code, body, calls)
+	}
+	if code, body := do(`{}`); code != 400 || !strings.Contains(body, "name is empty") {
+		t.Errorf("interceptor error should be written by error handler: %d %s", code, body)
+	}
+	if strings.Join(audit, ",") != "bob,cached," {
+		t.Errorf("group interceptor should see every request: %v", audit)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("interceptors of other types should panic")
+		}
+	}()
+	RegisterAPI(group, gine, "POST", "/other", func(ctx *gin.Context, req *struct{}) *interceptResponse {
+		return nil
+	}, WithInterceptors[interceptRequest, interceptResponse]())
+}
+
+func TestInterceptorWrongResponse(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	group.Intercept(func(ctx *gin.Context, req any, next func(req any) (any, error)) (any, error) {
+		return &struct{}{}, nil
+	})
+	RegisterAPI(group, gine, "POST", "/hello", func(ctx *gin.Context, req *interceptRequest) *interceptResponse {
+		return &interceptResponse{Greeting: "hello"}
+	}, WithErrHandler(func(ctx *gin.Context, err error) {
+		abortWithStatusJson(ctx, 500, &ErrorResponse{Error: err.Error()})
+	}))
+	w := httptest.NewRecorder()
+	gine.ServeHTTP(w, httptest.NewRequest("POST", "/hello", strings.NewReader(`{}`)))
+	if w.Code != 500 || !strings.Contains(w.Body.String(), "interceptor returned *struct {}") {
+		t.Errorf("response of wrong type should be an error: %d %s", w.Code, w.Body.String())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterGin should reject typed interceptors")
+		}
+	}()
+	group.RegisterGin(gine, &interceptRequest{}, &interceptResponse{}, "POST", "/gin", func(c *gin.Context) {},
+		WithInterceptors[interceptRequest, interceptResponse]())
+}
@@ -0,0 +1,67 @@
+package swagger
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorDoc documents an error response of an api
+type ErrorDoc struct {
+	Status      int    `json:"status"`
+	Description string `json:"description,omitempty"`
+	Example     any    `json:"example,omitempty"`
+}
+
+// ExampleJson formats the example in one line for docs
+func (e *ErrorDoc) ExampleJson() string {
+	if e.Example == nil {
+		return ""
+	}
+	bs, _ := json.Marshal(e.Example)
+	return string(bs)
+}
+
+// HeaderDoc documents a request header read by a middleware
+type HeaderDoc struct {
+	Name        string
+	Description string
+	Example     string
+	Required    bool
+}
+
+// MiddlewareDoc documents what middlewares add to the apis they decorate
+type MiddlewareDoc struct {
+	Headers []*HeaderDoc
+	Errors  []*ErrorDoc
+}
+
+// WithMiddleware installs gin middlewares for the api, they run after the security checks and
+// common params of the group and before the handler
+func WithMiddleware(handlers ...gin.HandlerFunc) OptFunc {
+	return func(o *Api) {
+		o.middleware = append(o.middleware, handlers...)
+	}
+}
+
+// WithDocumentedMiddleware installs middlewares like WithMiddleware, and adds the headers and error responses
+// of doc to the docs of the api
+func WithDocumentedMiddleware(doc *MiddlewareDoc, handlers ...gin.HandlerFunc) OptFunc {
+	return func(o *Api) {
+		o.middleware = append(o.middleware, handlers...)
+		for _, h := range doc.Headers {
+			if o.RequestSchema.Properties == nil {
+				o.RequestSchema.Properties = map[string]*Schema{}
+			}
+			o.RequestSchema.Properties[h.Name] = &Schema{Type: "string", Location: "header", Description: h.Description,
+				Example: h.Example, Required: h.Required}
+		}
+		o.Errors = append(o.Errors, doc.Errors...)
+	}
+}
+
+// WithErrors documents error responses of the api
+func WithErrors(errs ...*ErrorDoc) OptFunc {
+	return func(o *Api) {
+		o.Errors = append(o.Errors, errs...)
+	}
+}
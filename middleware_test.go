@@ -0,0 +1,91 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type middlewareApis struct{}
+
+func (middlewareApis) ApiPing() any {
+	return ApiTemplate[struct{}, securityResponse]{
+		Title:  "ping",
+		Method: "GET",
+		Path:   "/ping",
+		Handler: func(ctx *gin.Context, req *struct{}) *securityResponse {
+			return &securityResponse{User: ctx.GetString("client")}
+		},
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	clientDoc := &MiddlewareDoc{
+		Headers: []*HeaderDoc{{Name: "X-Client", Description: "client id", Example: "app", Required: true}},
+		Errors:  []*ErrorDoc{{Status: 429, Description: "too many requests", Example: &ErrorResponse{Error: "rate limited"}}},
+	}
+	client := func(c *gin.Context) {
+		if c.GetHeader("X-Client") == "" {
+			abortWithStatusJson(c, 429, &ErrorResponse{Error: "rate limited"})
+			return
+		}
+		c.Set("client", c.GetHeader("X-Client"))
+	}
+	handler := func(ctx *gin.Context, req *struct{}) *securityResponse {
+		return &securityResponse{User: ctx.GetString("client")}
+	}
+	RegisterAPI(group, gine, "GET", "/typed", handler, WithTitle("typed"), WithDocumentedMiddleware(clientDoc, client))
+	group.RegisterGin(gine, &struct{}{}, &securityResponse{}, "GET", "/gin", func(c *gin.Context) {
+		abortWithStatusJson(c, 200, &securityResponse{User: c.GetString("client")})
+	}, WithTitle("gin"), WithMiddleware(client))
+	group.RegisterAllApi(gine, middlewareApis{}, func(name string) bool {
+		return strings.HasPrefix(name, "Api")
+	}, WithMiddleware(client))
+
+	for _, url := range []string{"/typed", "/gin", "/ping"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", url, nil)
+		r.Header.Set("X-Client", "app")
+		gine.ServeHTTP(w, r)
+		if w.Code != 200 || !strings.Contains(w.Body.String(), `"app"`) {
+			t.Errorf("%s should run middleware before handler: %d %s", url, w.Code, w.Body.String())
+		}
+		w = httptest.NewRecorder()
+		gine.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		if w.Code != 429 {
+			t.Errorf("%s middleware should abort, got %d", url, w.Code)
+		}
+		w = httptest.NewRecorder()
+		gine.ServeHTTP(w, httptest.NewRequest("GET", url+"?get_schema=true", nil))
+		if w.Code != 200 {
+			t.Errorf("%s get_schema should run before middleware, got %d", url, w.Code)
+		}
+	}
+
+	md := group.GenerateMarkdown()
+	for _, s := range []string{
+		"|X-Client|string||true|header||client id|",
+		"**错误响应**",
+		"|429|too many requests|`{\"error\":\"rate limited\"}`|",
+	} {
+		if !strings.Contains(md, s) {
+			t.Errorf("markdown should contain %q:\n%s", s, md)
+		}
+	}
+	spec := group.GenerateOpenAPI("api", "1.0.0")
+	if res := spec.Paths["/typed"]["get"].Responses["429"]; res == nil || res.Description != "too many requests" {
+		t.Errorf("openapi should document middleware errors: %v", res)
+	}
+	required := false
+	for _, p := range spec.Paths["/typed"]["get"].Parameters {
+		if p.Name == "X-Client" {
+			required = p.Required
+		}
+	}
+	if !required {
+		t.Error("openapi should document the middleware header as required")
+	}
+}
@@ -0,0 +1,138 @@
+package swagger
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"reflect"
+)
+
+// MockScenarioHeader selects the mock scenario of a mocked api, empty or "success" responds the example of response schema
+const MockScenarioHeader = "X-Mock-Scenario"
+
+const MockScenarioSuccess = "success"
+
+// MockScenario is a named response of a mocked api
+type MockScenario struct {
+	Name   string `json:"name"`
+	Status int    `json:"status"`
+	Body   any    `json:"body,omitempty"`
+}
+
+// WithMock makes the api respond mock data instead of calling the handler
+func WithMock() OptFunc {
+	return func(o *Api) {
+		o.mock = true
+	}
+}
+
+// WithMockScenario declares a named scenario which can be selected by MockScenarioHeader, ErrorResponse is used as body if body is nil
+func WithMockScenario(name string, status int, body any) OptFunc {
+	return func(o *Api) {
+		if body == nil {
+			body = &ErrorResponse{Error: name}
+		}
+		o.MockScenarios = append(o.MockScenarios, &MockScenario{Name: name, Status: status, Body: body})
+	}
+}
+
+// SetMock switches mock mode of all apis in the group, sub-groups follow it unless they set their own
+func (a *ApiGroup) SetMock(enable bool) {
+	a.mock.Store(enable)
+	a.mockSet.Store(true)
+}
+
+// mockEnabled returns the mock mode of the group or its nearest parent setting it
+func (a *ApiGroup) mockEnabled() bool {
+	for g := a; g != nil; g = g.parent {
+		if g.mockSet.Load() {
+			return g.mock.Load()
+		}
+	}
+	return false
+}
+
+func (a *Api) mockScenario(name string) *MockScenario {
+	for _, s := range a.MockScenarios {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func (a *ApiGroup) mockHandler(api *Api) gin.HandlerFunc {
+	errHandler := api.ErrHandler
+	if errHandler == nil {
+		errHandler = defaultErrHandler
+	}
+	return func(c *gin.Context) {
+		if !api.mock && !a.mockEnabled() {
+			c.Next()
+			return
+		}
+
+		var err error
+		if api.Request != nil {
+			typ := reflect.TypeOf(api.Request)
+			if typ.Kind() == reflect.Ptr {
+				typ = typ.Elem()
+			}
+			err = bindRequest(a, c, reflect.New(typ).Interface())
+		} else if api.RequestSchema != nil {
+			err = api.RequestSchema.validateRequest(c)
+		}
+		if err != nil {
+			if !c.IsAborted() {
+				errHandler(c, err)
+			}
+			c.Abort()
+			return
+		}
+
+		name := c.GetHeader(MockScenarioHeader)
+		if scenario := api.mockScenario(name); scenario != nil {
+			abortWithStatusJson(c, scenario.Status, scenario.Body)
+			return
+		}
+		if name != "" && name != MockScenarioSuccess {
+			abortWithStatusJson(c, 400, &ErrorResponse{Error: fmt.Sprintf("mock scenario '%s' is not declared", name)})
+			return
+		}
+		var res any
+		if api.ResponseSchema != nil {
+			res = api.ResponseSchema.GenExample()
+		}
+		abortWithStatusJson(c, api.successStatus(), res)
+	}
+}
+
+// RegisterMockApis registers mocked apis loaded from the json dumped by HandlerAllApiSchemas,
+// requests are validated against the request schema. The returned group can be used to serve docs.
+// Dumped routes already contain the version, versions of header or media type versioning sharing
+// a route are documented but only the first one is served. Permissions are only documented, every caller is
+// allowed until an authorizer is set on the returned group.
+func RegisterMockApis(router BasicRouter, data []byte) (*ApiGroup, error) {
+	apis, err := LoadApis(data)
+	if err != nil {
+		return nil, err
+	}
+	a := NewAPIGroup()
+	a.SetAuthorizer(AuthorizerFunc(func(c *gin.Context, api *Api, principal any) error {
+		return nil
+	}))
+	routed := map[string]bool{}
+	for _, api := range apis {
+		if api.Method == "" || api.Route == "" {
+			return nil, fmt.Errorf("api '%s' has no method or route", api.Title)
+		}
+		api.mock = true
+		a.applyDefaults(api)
+		if key := api.Method + " " + api.Route; !routed[key] {
+			routed[key] = true
+			a.route(router, api, api.Route, func(c *gin.Context) {})
+		} else {
+			a.add(api)
+		}
+	}
+	return a, nil
+}
@@ -0,0 +1,165 @@
+package swagger
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type mockUserRequest struct {
+	Id   int    `json:"id" location:"path,id" required:"true"`
+	Kind string `json:"kind" location:"query,kind" enum:"a,b"`
+	Name string `json:"name" binding:"required" required:"true"`
+}
+
+type mockUserResponse struct {
+	Id   int    `json:"id" example:"12"`
+	Name string `json:"name" example:"user01"`
+}
+
+func serveMock(gine *gin.Engine, method, url, body, scenario string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(method, url, strings.NewReader(body))
+	if scenario != "" {
+		r.Header.Set(MockScenarioHeader, scenario)
+	}
+	gine.ServeHTTP(w, r)
+	return w
+}
+
+func TestMockAPI(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	called := false
+	RegisterAPI(group, gine.Group("/api"), "POST", "/users/:id", func(ctx *gin.Context, req *mockUserRequest) *mockUserResponse {
+		called = true
+		return &mockUserResponse{}
+	}, WithMock(), WithMockScenario("not_found", 404, nil))
+
+	w := serveMock(gine, "POST", "/api/users/1", `{"name":"x"}`, "")
+	if w.Code != 200 || w.Body.String() != `{"id":12,"name":"user01"}` {
+		t.Fatalf("unexpected mock response: %d %s", w.Code, w.Body.String())
+	}
+	w = serveMock(gine, "POST", "/api/users/1", `{}`, "")
+	if w.Code != 400 {
+		t.Fatalf("expect binding error, got %d %s", w.Code, w.Body.String())
+	}
+	w = serveMock(gine, "POST", "/api/users/1", `{"name":"x"}`, "not_found")
+	if w.Code != 404 || w.Body.String() != `{"error":"not_found"}` {
+		t.Fatalf("unexpected scenario response: %d %s", w.Code, w.Body.String())
+	}
+	w = serveMock(gine, "POST", "/api/users/1", `{"name":"x"}`, "unknown")
+	if w.Code != 400 {
+		t.Fatalf("expect unknown scenario error, got %d", w.Code)
+	}
+	if called {
+		t.Fatal("handler should not be called in mock mode")
+	}
+}
+
+func TestMockGroupSwitch(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	group.RegisterGin(gine, mockUserRequest{}, mockUserResponse{}, "POST", "/users/:id", func(ctx *gin.Context) {
+		ctx.JSON(200, &mockUserResponse{Id: 1, Name: "real"})
+	})
+
+	w := serveMock(gine, "POST", "/users/1", `{}`, "")
+	if w.Body.String() != `{"id":1,"name":"real"}` {
+		t.Fatalf("expect real handler, got %s", w.Body.String())
+	}
+	group.SetMock(true)
+	w = serveMock(gine, "POST", "/users/1", `{"name":"x"}`, "")
+	if w.Body.String() != `{"id":12,"name":"user01"}` {
+		t.Fatalf("expect mock response, got %s", w.Body.String())
+	}
+}
+
+func TestRegisterMockApis(t *testing.T) {
+	src := NewAPIGroup()
+	RegisterAPI(src, gin.New().Group("/api"), "POST", "/users/:id", func(ctx *gin.Context, req *mockUserRequest) *mockUserResponse {
+		return nil
+	}, WithTitle("create user"), WithMockScenario("conflict", 409, nil))
+	data, err := json.Marshal(src.apis)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gine := gin.New()
+	group, err := RegisterMockApis(gine, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(group.apis) != 1 || group.apis[0].Route != "/api/users/:id" {
+		t.Fatalf("unexpected apis: %+v", group.apis)
+	}
+
+	cases := []struct {
+		url, body, scenario string
+		code                int
+		resp                string
+	}{
+		{"/api/users/1?kind=a", `{"name":"x"}`, "", 200, `{"id":12,"name":"user01"}`},
+		{"/api/users/1", `{"name":"x"}`, "conflict", 409, `{"error":"conflict"}`},
+		{"/api/users/1", `{}`, "", 400, `{"error":"'name' is required"}`},
+		{"/api/users/x", `{"name":"x"}`, "", 400, `{"error":"'id' should be integer"}`},
+		{"/api/users/1?kind=c", `{"name":"x"}`, "", 400, `{"error":"'kind' must be oneof 'a b'"}`},
+		{"/api/users/1", `{"name":1}`, "", 400, `{"error":"'name' should be string"}`},
+	}
+	for _, c := range cases {
+		w := serveMock(gine, "POST", c.url, c.body, c.scenario)
+		if w.Code != c.code || w.Body.String() != c.resp {
+			t.Errorf("%s %s: expect %d %s, got %d %s", c.url, c.body, c.code, c.resp, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestRegisterMockApisVersioned(t *testing.T) {
+	src := NewAPIGroup()
+	RegisterAPI(src, gin.New().Group("/api"), "GET", "/users", func(ctx *gin.Context, req *struct{}) *mockUserResponse {
+		return nil
+	}, WithTitle("list users"), WithVersions("v1", "v2"))
+	data, err := json.Marshal(src.apis)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gine := gin.New()
+	group, err := RegisterMockApis(gine, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, url := range []string{"/api/v1/users", "/api/v2/users"} {
+		w := serveMock(gine, "GET", url, "", "")
+		if w.Code != 200 || w.Body.String() != `{"id":12,"name":"user01"}` {
+			t.Errorf("%s should be mocked, got %d %s", url, w.Code, w.Body.String())
+		}
+	}
+	if apis := group.VersionGroup("v1").Apis(); len(apis) != 1 || apis[0].Route != "/api/v1/users" {
+		t.Errorf("dumped versions should be kept for docs: %+v", apis)
+	}
+}
+
+func TestRegisterMockApisPermissions(t *testing.T) {
+	src := NewAPIGroup()
+	RegisterAPI(src, gin.New(), "GET", "/admin/users", func(ctx *gin.Context, req *struct{}) *mockUserResponse {
+		return nil
+	}, WithTitle("list users"), WithPermissions("user:read"))
+	gine := gin.New()
+	group, err := RegisterMockApis(gine, mustJson(src.apis))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := serveMock(gine, "GET", "/admin/users", "", ""); w.Code != 200 || w.Body.String() != `{"id":12,"name":"user01"}` {
+		t.Errorf("apis with permissions should be mocked, got %d %s", w.Code, w.Body.String())
+	}
+	group.SetAuthorizer(AuthorizerFunc(func(c *gin.Context, api *Api, principal any) error {
+		return errors.New("denied")
+	}))
+	if w := serveMock(gine, "GET", "/admin/users", "", ""); w.Code != 403 {
+		t.Errorf("authorizer set on the mock group should apply, got %d", w.Code)
+	}
+}
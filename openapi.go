@@ -0,0 +1,381 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var errorResponseSchema = generateSchema(reflect.ValueOf(&ErrorResponse{}), "")
+
+type OpenAPI struct {
+	OpenAPI  string                     `json:"openapi"`
+	Info     OpenAPIInfo                `json:"info"`
+	Tags     []*OpenAPITag              `json:"tags,omitempty"`
+	Paths    map[string]OpenAPIPathItem `json:"paths"`
+	Webhooks map[string]OpenAPIPathItem `json:"webhooks,omitempty"`
+
+	Components *OpenAPIComponents `json:"components,omitempty"`
+}
+
+type OpenAPIComponents struct {
+	SecuritySchemes map[string]*OpenAPISecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type OpenAPISecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// OpenAPISecurityRequirement maps scheme name to scopes
+type OpenAPISecurityRequirement map[string][]string
+
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type OpenAPITag struct {
+	Name string `json:"name"`
+}
+
+// OpenAPIPathItem maps lower case http method to operation
+type OpenAPIPathItem map[string]*OpenAPIOperation
+
+type OpenAPIOperation struct {
+	Tags        []string                    `json:"tags,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	OperationId string                      `json:"operationId,omitempty"`
+	Parameters  []*OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+	Deprecated  bool                        `json:"deprecated,omitempty"`
+
+	Security []OpenAPISecurityRequirement `json:"security,omitempty"`
+}
+
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Required    bool           `json:"required,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Schema      *OpenAPISchema `json:"schema,omitempty"`
+	Example     any            `json:"example,omitempty"`
+	Deprecated  bool           `json:"deprecated,omitempty"`
+}
+
+type OpenAPIRequestBody struct {
+	Description string                       `json:"description,omitempty"`
+	Required    bool                         `json:"required,omitempty"`
+	Content     map[string]*OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Headers     map[string]*OpenAPIHeader    `json:"headers,omitempty"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+type OpenAPIHeader struct {
+	Description string         `json:"description,omitempty"`
+	Schema      *OpenAPISchema `json:"schema,omitempty"`
+}
+
+type OpenAPIMediaType struct {
+	Schema  *OpenAPISchema `json:"schema,omitempty"`
+	Example any            `json:"example,omitempty"`
+}
+
+type OpenAPISchema struct {
+	Type        string                    `json:"type,omitempty"`
+	Properties  map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required    []string                  `json:"required,omitempty"`
+	Items       *OpenAPISchema            `json:"items,omitempty"`
+	Enum        []any                     `json:"enum,omitempty"`
+	MaxLength   *int                      `json:"maxLength,omitempty"`
+	Description string                    `json:"description,omitempty"`
+	Default     any                       `json:"default,omitempty"`
+	Example     any                       `json:"example,omitempty"`
+	Deprecated  bool                      `json:"deprecated,omitempty"`
+}
+
+// GenerateOpenAPI generates the spec for the audience, public by default
+func (a *ApiGroup) GenerateOpenAPI(title, version string, audience ...Audience) *OpenAPI {
+	return GenerateOpenAPI(filterApis(a.apis, audienceOf(audience)), a.webhooks, title, version)
+}
+
+// HandlerOpenAPI serves the spec of the audience resolved by SetAudienceResolver, specs of versioned apis
+// are served per version by query "version" with the api version as info version
+func (a *ApiGroup) HandlerOpenAPI(title, version string) gin.HandlerFunc {
+	specs := map[string]map[Audience]*OpenAPI{}
+	for v, g := range a.versionViews() {
+		specs[v] = map[Audience]*OpenAPI{}
+		for _, aud := range audiences {
+			if v != "" {
+				specs[v][aud] = g.GenerateOpenAPI(title, v, aud)
+			} else {
+				specs[v][aud] = g.GenerateOpenAPI(title, version, aud)
+			}
+		}
+	}
+	return func(c *gin.Context) {
+		spec, ok := specs[a.requestedVersion(c)]
+		if !ok {
+			abortWithStatusJson(c, 404, &ErrorResponse{Error: "unknown version"})
+			return
+		}
+		abortWithStatusJson(c, 200, spec[a.audience(c)])
+	}
+}
+
+func GenerateOpenAPI(apis []*Api, webhooks []*Webhook, title, version string) *OpenAPI {
+	doc := &OpenAPI{
+		OpenAPI: "3.1.0",
+		Info: OpenAPIInfo{
+			Title:   title,
+			Version: version,
+		},
+		Paths: map[string]OpenAPIPathItem{},
+	}
+	tags := map[string]bool{}
+	for _, api := range apis {
+		if api.unexported {
+			continue
+		}
+		pth := openAPIPath(api.Route)
+		item := doc.Paths[pth]
+		if item == nil {
+			item = OpenAPIPathItem{}
+			doc.Paths[pth] = item
+		}
+		item[strings.ToLower(api.Method)] = api.openAPIOperation()
+		for _, s := range api.Security {
+			if doc.Components == nil {
+				doc.Components = &OpenAPIComponents{SecuritySchemes: map[string]*OpenAPISecurityScheme{}}
+			}
+			doc.Components.SecuritySchemes[s.Name] = &OpenAPISecurityScheme{
+				Type:         s.Type,
+				Scheme:       s.Scheme,
+				BearerFormat: s.BearerFormat,
+				In:           s.In,
+				Name:         s.Key,
+				Description:  s.Description,
+			}
+		}
+		for _, tag := range api.Tags {
+			if !tags[tag] {
+				tags[tag] = true
+				doc.Tags = append(doc.Tags, &OpenAPITag{Name: tag})
+			}
+		}
+	}
+	if len(webhooks) > 0 {
+		doc.Webhooks = map[string]OpenAPIPathItem{}
+		for _, w := range webhooks {
+			doc.Webhooks[w.Name] = OpenAPIPathItem{
+				"post": {
+					Summary:     w.Title,
+					Description: w.Description,
+					OperationId: w.Name,
+					RequestBody: &OpenAPIRequestBody{
+						Required: true,
+						Content: map[string]*OpenAPIMediaType{
+							"application/json": {
+								Schema:  w.PayloadSchema.toOpenAPI(true),
+								Example: w.PayloadSchema.GenExample(),
+							},
+						},
+					},
+					Responses: map[string]*OpenAPIResponse{
+						"200": {Description: "webhook received"},
+					},
+				},
+			}
+		}
+	}
+	return doc
+}
+
+func (a *Api) openAPIOperation() *OpenAPIOperation {
+	op := &OpenAPIOperation{
+		Tags:        a.Tags,
+		Summary:     a.Title,
+		Deprecated:  a.Deprecated != nil,
+		Description: a.Description,
+		Parameters:  a.RequestSchema.openAPIParameters(),
+		Responses: map[string]*OpenAPIResponse{
+			strconv.Itoa(a.successStatus()): {
+				Description: "success",
+				Content: map[string]*OpenAPIMediaType{
+					"application/json": {
+						Schema:  a.ResponseSchema.toOpenAPI(true),
+						Example: a.ResponseSchema.GenExample(),
+					},
+				},
+			},
+			"400": {
+				Description: "bad request",
+				Content: map[string]*OpenAPIMediaType{
+					"application/json": {
+						Schema: errorResponseSchema.toOpenAPI(true),
+					},
+				},
+			},
+		},
+	}
+	if a.Async {
+		res := op.Responses[strconv.Itoa(a.successStatus())]
+		res.Description = "accepted, poll the operation at Location"
+		res.Headers = map[string]*OpenAPIHeader{
+			"Location": {Description: "url of the operation status", Schema: &OpenAPISchema{Type: "string"}},
+		}
+	}
+	for _, s := range a.Security {
+		op.Security = append(op.Security, OpenAPISecurityRequirement{s.Name: {}})
+	}
+	if len(a.Security) > 0 {
+		op.Responses["401"] = &OpenAPIResponse{
+			Description: "unauthorized",
+			Content: map[string]*OpenAPIMediaType{
+				"application/json": {
+					Schema: errorResponseSchema.toOpenAPI(true),
+				},
+			},
+		}
+	}
+	for _, e := range a.Errors {
+		code := strconv.Itoa(e.Status)
+		if _, ok := op.Responses[code]; !ok {
+			op.Responses[code] = &OpenAPIResponse{
+				Description: e.Description,
+				Content: map[string]*OpenAPIMediaType{
+					"application/json": {
+						Schema:  errorResponseSchema.toOpenAPI(true),
+						Example: e.Example,
+					},
+				},
+			}
+		}
+	}
+	for _, s := range a.MockScenarios {
+		code := strconv.Itoa(s.Status)
+		if _, ok := op.Responses[code]; !ok {
+			op.Responses[code] = &OpenAPIResponse{
+				Description: s.Name,
+				Content: map[string]*OpenAPIMediaType{
+					"application/json": {Example: s.Body},
+				},
+			}
+		}
+	}
+	body := a.RequestSchema.toOpenAPI(true)
+	if len(body.Properties) > 0 {
+		op.RequestBody = &OpenAPIRequestBody{
+			Required: len(body.Required) > 0,
+			Content: map[string]*OpenAPIMediaType{
+				"application/json": {
+					Schema:  body,
+					Example: a.RequestSchema.GenExample(),
+				},
+			},
+		}
+	}
+	return op
+}
+
+func (s *Schema) openAPIParameters() []*OpenAPIParameter {
+	params := []*OpenAPIParameter{}
+	s.Walk(func(name string, node *Schema) bool {
+		switch node.Location {
+		case "path", "query", "header":
+			p := &OpenAPIParameter{
+				Name:        name,
+				In:          node.Location,
+				Required:    node.Required || node.Location == "path",
+				Description: node.Description,
+				Schema:      node.toOpenAPI(false),
+				Deprecated:  node.Deprecated,
+			}
+			if ex := node.getExample(); ex != "" && ex != "-" {
+				p.Example = formatByType(node.Type, ex, itemsType(node))
+			}
+			params = append(params, p)
+		}
+		return true
+	})
+	sort.Slice(params, func(i, j int) bool {
+		pi, pj := priority[params[i].In], priority[params[j].In]
+		if pi == pj {
+			return params[i].Name < params[j].Name
+		}
+		return pi > pj
+	})
+	return params
+}
+
+func itemsType(s *Schema) string {
+	if s.Items != nil {
+		return s.Items.Type
+	}
+	return ""
+}
+
+// toOpenAPI converts schema to openapi schema, if body is true, only json fields are kept
+func (s *Schema) toOpenAPI(body bool) *OpenAPISchema {
+	if s == nil {
+		return nil
+	}
+	o := &OpenAPISchema{
+		Description: s.Description,
+		MaxLength:   s.MaxLength,
+		Deprecated:  s.Deprecated,
+	}
+	if s.Type != "any" {
+		o.Type = s.Type
+	}
+	for _, e := range s.Enum {
+		o.Enum = append(o.Enum, formatByType(s.Type, e, ""))
+	}
+	if s.Default != "" {
+		o.Default = formatByType(s.Type, s.Default, itemsType(s))
+	}
+	if s.Example != "" && s.Example != "-" && s.Type != "object" {
+		o.Example = formatByType(s.Type, s.Example, itemsType(s))
+	}
+	if s.Items != nil {
+		o.Items = s.Items.toOpenAPI(body)
+	}
+	if len(s.Properties) > 0 {
+		o.Properties = map[string]*OpenAPISchema{}
+		for name, p := range s.Properties {
+			if body && p.Location != "" && p.Location != "json" {
+				continue
+			}
+			o.Properties[name] = p.toOpenAPI(body)
+			if p.Required {
+				o.Required = append(o.Required, name)
+			}
+		}
+		sort.Strings(o.Required)
+	}
+	return o
+}
+
+// openAPIPath converts gin route params (:id, *path) to openapi style ({id}, {path})
+func openAPIPath(route string) string {
+	segs := strings.Split(route, "/")
+	for i, seg := range segs {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			segs[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segs, "/")
+}
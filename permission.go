@@ -0,0 +1,191 @@
+package swagger
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed access_matrix_template.md
+var accessMatrixMarkdownTlp string
+
+//go:embed access_matrix_template.html
+var accessMatrixHtmlTlp string
+
+// PermissionAll grants every permission to a role
+const PermissionAll = "*"
+
+// Authorizer decides whether the principal may call the api, it runs after security verifiers
+// and before the typed handler. A non nil error rejects the request with 403.
+type Authorizer interface {
+	Authorize(c *gin.Context, api *Api, principal any) error
+}
+
+// AuthorizerFunc adapts a function to Authorizer
+type AuthorizerFunc func(c *gin.Context, api *Api, principal any) error
+
+func (f AuthorizerFunc) Authorize(c *gin.Context, api *Api, principal any) error {
+	return f(c, api, principal)
+}
+
+// WithPermissions sets the permissions required to call the api, all of them are required
+func WithPermissions(permissions ...string) OptFunc {
+	return func(o *Api) {
+		o.Permissions = append(o.Permissions, permissions...)
+	}
+}
+
+// SetAuthorizer sets the authorizer checking permissions of apis, apis without permissions are not checked.
+// Sub-groups without their own authorizer use the one of the nearest parent at request time.
+// Apis with permissions reject every request with 403 until an authorizer is set.
+func (a *ApiGroup) SetAuthorizer(authorizer Authorizer) {
+	a.authorizer = authorizer
+}
+
+// authorizerOf returns the authorizer of the group or its nearest parent
+func (a *ApiGroup) authorizerOf() Authorizer {
+	for g := a; g != nil; g = g.parent {
+		if g.authorizer != nil {
+			return g.authorizer
+		}
+	}
+	return nil
+}
+
+// RolePermissions maps role to granted permissions, PermissionAll grants every permission
+type RolePermissions map[string][]string
+
+// Allowed reports whether the roles together hold all permissions
+func (r RolePermissions) Allowed(roles []string, permissions []string) bool {
+	granted := map[string]bool{}
+	for _, role := range roles {
+		for _, p := range r[role] {
+			granted[p] = true
+		}
+	}
+	if granted[PermissionAll] {
+		return true
+	}
+	for _, p := range permissions {
+		if !granted[p] {
+			return false
+		}
+	}
+	return true
+}
+
+func (r RolePermissions) roles() []string {
+	roles := []string{}
+	for role := range r {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// RoleAuthorizer authorizes by roles of the principal, RolesOf returns roles of the principal set by security verifiers
+type RoleAuthorizer struct {
+	Roles   RolePermissions
+	RolesOf func(c *gin.Context, principal any) []string
+}
+
+func (r *RoleAuthorizer) Authorize(c *gin.Context, api *Api, principal any) error {
+	roles := r.RolesOf(c, principal)
+	if !r.Roles.Allowed(roles, api.Permissions) {
+		return fmt.Errorf("permissions %s are required", strings.Join(api.Permissions, ","))
+	}
+	return nil
+}
+
+func (a *ApiGroup) authorizeHandler(api *Api) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(api.Permissions) == 0 {
+			c.Next()
+			return
+		}
+		authorizer := a.authorizerOf()
+		if authorizer == nil {
+			abortWithStatusJson(c, 403, &ErrorResponse{Error: "forbidden: no authorizer to check permissions"})
+			return
+		}
+		if err := authorizer.Authorize(c, api, Principal(c)); err != nil {
+			if !c.IsAborted() {
+				abortWithStatusJson(c, 403, &ErrorResponse{Error: "forbidden: " + err.Error()})
+			}
+			return
+		}
+		c.Next()
+	}
+}
+
+type accessMatrixData struct {
+	Roles []string
+	Rows  []*accessRow
+	// Unprotected counts apis without security and permissions
+	Unprotected int
+}
+
+type accessRow struct {
+	Api     *Api
+	Allowed []bool
+}
+
+// Public reports whether the api is open to anonymous callers
+func (r *accessRow) Public() bool {
+	return len(r.Api.Security) == 0 && len(r.Api.Permissions) == 0
+}
+
+func (a *ApiGroup) newAccessMatrix(roles RolePermissions) *accessMatrixData {
+	data := &accessMatrixData{Roles: roles.roles()}
+	for _, api := range a.apis {
+		if api.unexported {
+			continue
+		}
+		row := &accessRow{Api: api}
+		for _, role := range data.Roles {
+			row.Allowed = append(row.Allowed, roles.Allowed([]string{role}, api.Permissions))
+		}
+		if row.Public() {
+			data.Unprotected++
+		}
+		data.Rows = append(data.Rows, row)
+	}
+	return data
+}
+
+// GenerateAccessMatrix generates a markdown table of apis and the roles allowed to call them
+func (a *ApiGroup) GenerateAccessMatrix(roles RolePermissions) string {
+	return a.generateAccessMatrix(roles, accessMatrixMarkdownTlp)
+}
+
+// GenerateAccessMatrixHtml generates the access matrix as a html page
+func (a *ApiGroup) GenerateAccessMatrixHtml(roles RolePermissions) string {
+	return a.generateAccessMatrix(roles, accessMatrixHtmlTlp)
+}
+
+// HandlerAccessMatrix serves the html access matrix
+func (a *ApiGroup) HandlerAccessMatrix(roles RolePermissions) gin.HandlerFunc {
+	page := a.GenerateAccessMatrixHtml(roles)
+	return func(c *gin.Context) {
+		c.Writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		c.Writer.WriteHeader(200)
+		c.Writer.WriteString(page)
+	}
+}
+
+func (a *ApiGroup) generateAccessMatrix(roles RolePermissions, tlp string) string {
+	t, err := template.New("access").Parse(tlp)
+	if err != nil {
+		panic(err)
+	}
+	bf := &bytes.Buffer{}
+	err = t.Execute(bf, a.newAccessMatrix(roles))
+	if err != nil {
+		panic(err)
+	}
+	return bf.String()
+}
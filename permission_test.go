@@ -0,0 +1,80 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPermissions(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	roles := RolePermissions{
+		"admin":  {PermissionAll},
+		"editor": {"order:read", "order:write"},
+		"viewer": {"order:read"},
+	}
+	group.SetDefaultSecurity(ApiKeyAuth("apiKey", "header", "X-Role", func(c *gin.Context, role string) (any, error) {
+		return role, nil
+	}))
+	group.SetAuthorizer(&RoleAuthorizer{Roles: roles, RolesOf: func(c *gin.Context, principal any) []string {
+		return []string{principal.(string)}
+	}})
+	handler := func(ctx *gin.Context, req *struct{}) *securityResponse {
+		return &securityResponse{}
+	}
+	RegisterAPI(group, gine, "GET", "/orders", handler, WithTitle("list orders"), WithPermissions("order:read"))
+	RegisterAPI(group, gine, "POST", "/orders", handler, WithTitle("create order"), WithPermissions("order:write"))
+	RegisterAPI(group, gine, "GET", "/health", handler, WithTitle("health"), WithSecurity())
+
+	call := func(method, role string) int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, "/orders", nil)
+		r.Header.Set("X-Role", role)
+		gine.ServeHTTP(w, r)
+		return w.Code
+	}
+	for _, c := range []struct {
+		method, role string
+		code         int
+	}{
+		{"GET", "viewer", 200},
+		{"POST", "viewer", 403},
+		{"POST", "editor", 200},
+		{"POST", "admin", 200},
+		{"POST", "", 401},
+	} {
+		if code := call(c.method, c.role); code != c.code {
+			t.Errorf("%s /orders as %q: expect %d, got %d", c.method, c.role, c.code, code)
+		}
+	}
+
+	md := group.GenerateAccessMatrix(roles)
+	for _, s := range []string{
+		"> 共 1 个接口未配置鉴权和权限",
+		"|接口|鉴权方式|所需权限|admin|editor|viewer|",
+		"|`POST /orders` create order|apiKey|order:write|✓|✓|✗|",
+		"|`GET /health` health|公开||✓|✓|✓|",
+	} {
+		if !strings.Contains(md, s) {
+			t.Errorf("access matrix should contain %q:\n%s", s, md)
+		}
+	}
+	if html := group.GenerateAccessMatrixHtml(roles); !strings.Contains(html, `<td class="cell denied">✗</td>`) {
+		t.Error("html access matrix should mark denied roles")
+	}
+}
+
+func TestPermissionsWithoutAuthorizer(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	RegisterAPI(group, gine, "GET", "/admin", func(ctx *gin.Context, req *struct{}) *securityResponse {
+		return &securityResponse{User: "secret"}
+	}, WithPermissions("admin"))
+	w := httptest.NewRecorder()
+	gine.ServeHTTP(w, httptest.NewRequest("GET", "/admin", nil))
+	if w.Code != 403 || strings.Contains(w.Body.String(), "secret") {
+		t.Errorf("permissions without authorizer should fail closed: %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,205 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+type PostmanCollection struct {
+	Info     PostmanInfo    `json:"info"`
+	Item     []*PostmanItem `json:"item"`
+	Variable []*PostmanKV   `json:"variable,omitempty"`
+}
+
+type PostmanInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+// PostmanItem is a folder if Item is set, otherwise a request
+type PostmanItem struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Item        []*PostmanItem  `json:"item,omitempty"`
+	Request     *PostmanRequest `json:"request,omitempty"`
+}
+
+type PostmanRequest struct {
+	Method      string       `json:"method"`
+	Header      []*PostmanKV `json:"header"`
+	Url         *PostmanUrl  `json:"url"`
+	Body        *PostmanBody `json:"body,omitempty"`
+	Description string       `json:"description,omitempty"`
+}
+
+type PostmanUrl struct {
+	Raw      string       `json:"raw"`
+	Host     []string     `json:"host"`
+	Path     []string     `json:"path"`
+	Query    []*PostmanKV `json:"query,omitempty"`
+	Variable []*PostmanKV `json:"variable,omitempty"`
+}
+
+type PostmanKV struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+type PostmanBody struct {
+	Mode    string         `json:"mode"`
+	Raw     string         `json:"raw"`
+	Options map[string]any `json:"options,omitempty"`
+}
+
+// GeneratePostmanCollection exports exported apis as a postman v2.1 collection, requests are
+// grouped into folders by the first tag or route prefix and use the {{baseUrl}} collection variable.
+// Only apis visible to the audience are exported, public by default.
+func (a *ApiGroup) GeneratePostmanCollection(audience ...Audience) *PostmanCollection {
+	return GeneratePostmanCollection(filterApis(a.apis, audienceOf(audience)), "API")
+}
+
+// HandlerPostmanCollection serves the collection of the audience resolved by SetAudienceResolver
+func (a *ApiGroup) HandlerPostmanCollection() gin.HandlerFunc {
+	collections := map[Audience]*PostmanCollection{}
+	for _, aud := range audiences {
+		collections[aud] = a.GeneratePostmanCollection(aud)
+	}
+	return func(c *gin.Context) {
+		c.Writer.Header().Set("Content-Disposition", `attachment; filename="postman_collection.json"`)
+		abortWithStatusJson(c, 200, collections[a.audience(c)])
+	}
+}
+
+func GeneratePostmanCollection(apis []*Api, name string) *PostmanCollection {
+	col := &PostmanCollection{
+		Info: PostmanInfo{
+			Name:   name,
+			Schema: postmanSchema,
+		},
+		Item: []*PostmanItem{},
+		Variable: []*PostmanKV{
+			{Key: "baseUrl", Value: ExampleBaseUrl},
+		},
+	}
+	exported := []*Api{}
+	for _, api := range apis {
+		if !api.unexported {
+			exported = append(exported, api)
+		}
+	}
+	prefix := routesCommonPrefix(exported)
+	folders := map[string]*PostmanItem{}
+	for _, api := range exported {
+		folder := routeFolder(api.Route, prefix)
+		if len(api.Tags) > 0 {
+			folder = api.Tags[0]
+		}
+		f := folders[folder]
+		if f == nil {
+			f = &PostmanItem{Name: folder}
+			folders[folder] = f
+			col.Item = append(col.Item, f)
+		}
+		f.Item = append(f.Item, api.postmanItem())
+	}
+	return col
+}
+
+func (a *Api) postmanItem() *PostmanItem {
+	segs := strings.Split(strings.Trim(a.Route, "/"), "/")
+	url := &PostmanUrl{
+		Host: []string{"{{baseUrl}}"},
+		Path: []string{},
+	}
+	for _, seg := range segs {
+		if seg == "" {
+			continue
+		}
+		if seg[0] == ':' || seg[0] == '*' {
+			url.Variable = append(url.Variable, &PostmanKV{
+				Key:   seg[1:],
+				Value: strings.TrimPrefix(a.RequestSchema.examplePathParam(seg[1:]), "/"),
+			})
+			seg = ":" + seg[1:]
+		}
+		url.Path = append(url.Path, seg)
+	}
+	for _, q := range a.RequestSchema.genExampleQuery() {
+		k, v, _ := strings.Cut(q, "=")
+		url.Query = append(url.Query, &PostmanKV{Key: k, Value: v})
+	}
+	url.Raw = "{{baseUrl}}/" + strings.Join(url.Path, "/")
+	if len(url.Query) > 0 {
+		qs := make([]string, len(url.Query))
+		for i, q := range url.Query {
+			qs[i] = q.Key + "=" + q.Value
+		}
+		url.Raw += "?" + strings.Join(qs, "&")
+	}
+
+	req := &PostmanRequest{
+		Method:      a.Method,
+		Header:      []*PostmanKV{},
+		Url:         url,
+		Description: a.Description,
+	}
+	for _, h := range a.RequestSchema.genExampleHeader() {
+		k, v, _ := strings.Cut(h, ": ")
+		req.Header = append(req.Header, &PostmanKV{Key: k, Value: v})
+	}
+	if body := a.RequestSchema.GenExampleJson(); body != "{}" {
+		req.Header = append(req.Header, &PostmanKV{Key: "Content-Type", Value: "application/json"})
+		req.Body = &PostmanBody{
+			Mode: "raw",
+			Raw:  body,
+			Options: map[string]any{
+				"raw": map[string]any{"language": "json"},
+			},
+		}
+	}
+	name := a.Title
+	if name == "" {
+		name = a.Method + " " + a.Route
+	}
+	return &PostmanItem{
+		Name:    name,
+		Request: req,
+	}
+}
+
+// routesCommonPrefix returns the static path segments shared by all api routes
+func routesCommonPrefix(apis []*Api) []string {
+	var prefix []string
+	for i, api := range apis {
+		segs := strings.Split(strings.Trim(api.Route, "/"), "/")
+		segs = segs[:len(segs)-1]
+		if i == 0 {
+			prefix = segs
+			continue
+		}
+		n := 0
+		for n < len(prefix) && n < len(segs) && prefix[n] == segs[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	for i, seg := range prefix {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			return prefix[:i]
+		}
+	}
+	return prefix
+}
+
+func routeFolder(route string, prefix []string) string {
+	segs := strings.Split(strings.Trim(route, "/"), "/")
+	if len(segs) > len(prefix) {
+		if seg := segs[len(prefix)]; seg != "" {
+			return seg
+		}
+	}
+	return "/"
+}
@@ -0,0 +1,28 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"testing"
+)
+
+func TestGeneratePostmanCollection(t *testing.T) {
+	group := NewAPIGroup()
+	gine := gin.New()
+	RegisterAPI(group, gine.Group("/api/users"), "POST", "/:key/*path", HandlerReq, WithTitle("update user"))
+	RegisterAPI(group, gine.Group("/api/orders"), "GET", "/:key", HandlerReq, WithTitle("get order"))
+
+	col := group.GeneratePostmanCollection()
+	if len(col.Item) != 2 || col.Item[0].Name != "users" || col.Item[1].Name != "orders" {
+		t.Fatalf("unexpected folders: %+v", col.Item)
+	}
+	req := col.Item[0].Item[0].Request
+	if req.Url.Raw != "{{baseUrl}}/api/users/:key/:path?def=defs" {
+		t.Fatalf("unexpected url: %s", req.Url.Raw)
+	}
+	if len(req.Url.Variable) != 2 || req.Url.Variable[0].Value != "test_key" || req.Url.Variable[1].Value != "abc/ttx" {
+		t.Fatalf("unexpected path variables: %+v", req.Url.Variable)
+	}
+	if req.Body == nil || req.Body.Mode != "raw" {
+		t.Fatalf("body is not generated: %+v", req.Body)
+	}
+}
@@ -0,0 +1,270 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// RequestCase is a request generated from the request schema, invalid cases are expected to be rejected with 4xx
+type RequestCase struct {
+	Name   string
+	Valid  bool
+	Method string
+	Url    string
+	Header http.Header
+	Body   []byte
+}
+
+// NewRequest builds a http request of the case
+func (c *RequestCase) NewRequest(baseUrl string) (*http.Request, error) {
+	req, err := http.NewRequest(c.Method, baseUrl+c.Url, bytes.NewReader(c.Body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = c.Header.Clone()
+	return req, nil
+}
+
+// RequestCases generates the example request, valid boundary requests and invalid requests of the api,
+// invalid requests cover wrong types, out of enum values, missing required fields and values around binding min, max and len.
+func (a *Api) RequestCases() []*RequestCase {
+	g := &caseGen{api: a}
+	g.add("example", true, nil, "", nil)
+	for _, p := range collectParams(a.RequestSchema) {
+		g.paramCases(p.name, p.schema)
+	}
+	g.bodyCases(a.RequestSchema, nil)
+	return g.cases
+}
+
+type caseGen struct {
+	api   *Api
+	cases []*RequestCase
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// add generates a case from the examples with the param overridden or the body field at pth set,
+// the param or body field is removed if value is nil.
+func (g *caseGen) add(name string, valid bool, pth []string, param string, value any) {
+	rs := g.api.RequestSchema
+	c := &RequestCase{
+		Name:   name,
+		Valid:  valid,
+		Method: g.api.Method,
+		Header: http.Header{},
+	}
+
+	route := g.api.Route
+	query := url.Values{}
+	for _, p := range collectParams(rs) {
+		val := strPtr(exampleParamValue(p.name, p.schema))
+		if pth == nil && param == p.name {
+			val, _ = value.(*string)
+		}
+		switch p.schema.Location {
+		case "path":
+			v := ""
+			if val != nil {
+				v = url.PathEscape(*val)
+			}
+			route = strings.Replace(route, ":"+p.name, v, 1)
+			route = strings.Replace(route, "*"+p.name, v, 1)
+		case "query":
+			if val != nil {
+				query.Set(p.name, *val)
+			}
+		case "header":
+			if val != nil {
+				c.Header.Set(p.name, *val)
+			}
+		}
+	}
+	c.Url = route
+	if len(query) > 0 {
+		c.Url += "?" + query.Encode()
+	}
+
+	body := rs.GenExample()
+	if pth != nil {
+		setBodyField(body, pth, value)
+	}
+	if m, ok := body.(map[string]any); ok && len(m) == 0 && pth == nil {
+		body = nil
+	}
+	if body != nil {
+		c.Body, _ = json.Marshal(body)
+		c.Header.Set("Content-Type", "application/json")
+	}
+	g.cases = append(g.cases, c)
+}
+
+func exampleParamValue(name string, s *Schema) string {
+	if ex := s.getExample(); ex != "" && ex != "-" {
+		return ex
+	}
+	switch s.Type {
+	case "integer", "number":
+		return "1"
+	case "boolean":
+		return "true"
+	}
+	return name
+}
+
+func setBodyField(body any, pth []string, value any) {
+	m, ok := body.(map[string]any)
+	if !ok {
+		return
+	}
+	if len(pth) > 1 {
+		child, ok := m[pth[0]].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			m[pth[0]] = child
+		}
+		setBodyField(child, pth[1:], value)
+		return
+	}
+	if value == nil {
+		delete(m, pth[0])
+		return
+	}
+	m[pth[0]] = value
+}
+
+func (g *caseGen) paramCases(name string, s *Schema) {
+	add := func(desc string, valid bool, val *string) {
+		g.add(fmt.Sprintf("%s '%s' %s", s.Location, name, desc), valid, nil, name, val)
+	}
+	switch s.Type {
+	case "integer", "number", "boolean":
+		add("wrong type", false, strPtr("abc"))
+	}
+	if s.isRequired() && s.Location != "path" {
+		add("missing required", false, nil)
+	}
+	if len(s.Enum) > 0 {
+		add("out of enum", false, strPtr(fmt.Sprint(outOfEnum(s))))
+	}
+	for _, b := range s.boundaries() {
+		add(b.desc, b.valid, strPtr(fmt.Sprint(b.value)))
+	}
+}
+
+func (g *caseGen) bodyCases(s *Schema, pth []string) {
+	for _, name := range sortedProperties(s) {
+		p := s.Properties[name]
+		if !isBodyField(p) {
+			continue
+		}
+		name = jsonFieldName(name)
+		if name == "-" {
+			continue
+		}
+		fp := append(append([]string{}, pth...), name)
+		field := strings.Join(fp, ".")
+		add := func(desc string, valid bool, val any) {
+			g.add(fmt.Sprintf("body '%s' %s", field, desc), valid, fp, "", val)
+		}
+		switch p.Type {
+		case "string":
+			add("wrong type", false, 12345)
+		case "integer", "number", "boolean", "object", "array":
+			add("wrong type", false, "abc")
+		}
+		if p.isRequired() {
+			add("missing required", false, nil)
+		}
+		if len(p.Enum) > 0 {
+			add("out of enum", false, outOfEnum(p))
+		}
+		for _, b := range p.boundaries() {
+			add(b.desc, b.valid, b.value)
+		}
+		if p.Type == "object" {
+			g.bodyCases(p, fp)
+		}
+	}
+}
+
+func outOfEnum(s *Schema) any {
+	switch s.Type {
+	case "integer", "number":
+		max := 0.0
+		for _, e := range s.Enum {
+			f, _ := strconv.ParseFloat(e, 64)
+			if f > max {
+				max = f
+			}
+		}
+		return max + 1
+	}
+	return "out_of_enum"
+}
+
+type boundary struct {
+	desc  string
+	valid bool
+	value any
+}
+
+// boundaries returns values around binding min, max and len,
+// numbers are compared by value, strings and arrays by length.
+func (s *Schema) boundaries() []*boundary {
+	res := []*boundary{}
+	sized := func(n float64) any {
+		switch s.Type {
+		case "string":
+			return strings.Repeat("a", int(n))
+		case "array":
+			items := []any{}
+			for i := 0; i < int(n); i++ {
+				if s.Items != nil {
+					items = append(items, s.Items.GenExample())
+				}
+			}
+			return items
+		}
+		return n
+	}
+	add := func(desc string, valid bool, n float64) {
+		if s.Type != "integer" && s.Type != "number" && n < 0 {
+			return
+		}
+		res = append(res, &boundary{desc: desc, valid: valid, value: sized(n)})
+	}
+	switch s.Type {
+	case "string", "array", "integer", "number":
+	default:
+		return res
+	}
+	for _, rule := range bindingRules(s.Binding) {
+		k, v := rule[0], rule[1]
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			continue
+		}
+		switch k {
+		case "min", "gte":
+			add("at "+k+"="+v, true, n)
+			add("below "+k+"="+v, false, n-1)
+		case "max", "lte":
+			add("at "+k+"="+v, true, n)
+			add("above "+k+"="+v, false, n+1)
+		case "gt", "lt":
+			add("at "+k+"="+v, false, n)
+		case "len":
+			add("at "+k+"="+v, true, n)
+			add("above "+k+"="+v, false, n+1)
+		}
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"testing"
+)
+
+type caseRequest struct {
+	Id    int    `json:"id" location:"path,id" example:"3"`
+	Kind  string `json:"kind" location:"query,kind" enum:"a,b" required:"true"`
+	Name  string `json:"name" binding:"required,min=2,max=4" required:"true" example:"abc"`
+	Level int    `json:"level" binding:"gte=1" example:"1"`
+}
+
+func TestRequestCases(t *testing.T) {
+	group := NewAPIGroup()
+	api := RegisterAPI(group, gin.New(), "POST", "/items/:id", func(ctx *gin.Context, req *caseRequest) *response {
+		return nil
+	})
+
+	cases := map[string]*RequestCase{}
+	for _, c := range api.RequestCases() {
+		cases[c.Name] = c
+	}
+	expects := []struct {
+		name  string
+		valid bool
+		url   string
+		body  string
+	}{
+		{"example", true, "/items/3?kind=a", `{"level":1,"name":"abc"}`},
+		{"path 'id' wrong type", false, "/items/abc?kind=a", `{"level":1,"name":"abc"}`},
+		{"query 'kind' missing required", false, "/items/3", `{"level":1,"name":"abc"}`},
+		{"query 'kind' out of enum", false, "/items/3?kind=out_of_enum", `{"level":1,"name":"abc"}`},
+		{"body 'name' wrong type", false, "/items/3?kind=a", `{"level":1,"name":12345}`},
+		{"body 'name' missing required", false, "/items/3?kind=a", `{"level":1}`},
+		{"body 'name' at min=2", true, "/items/3?kind=a", `{"level":1,"name":"aa"}`},
+		{"body 'name' below min=2", false, "/items/3?kind=a", `{"level":1,"name":"a"}`},
+		{"body 'name' above max=4", false, "/items/3?kind=a", `{"level":1,"name":"aaaaa"}`},
+		{"body 'level' below gte=1", false, "/items/3?kind=a", `{"level":0,"name":"abc"}`},
+	}
+	for _, e := range expects {
+		c := cases[e.name]
+		if c == nil {
+			t.Errorf("case %q is not generated", e.name)
+			continue
+		}
+		if c.Valid != e.valid || c.Url != e.url || string(c.Body) != e.body {
+			t.Errorf("%s: unexpected case %v %s %s", e.name, c.Valid, c.Url, c.Body)
+		}
+	}
+}
+
+func TestRequestCasesBindingRequired(t *testing.T) {
+	group := NewAPIGroup()
+	api := RegisterAPI(group, gin.New(), "POST", "/items", func(ctx *gin.Context, req *struct {
+		Trace string `json:"trace" location:"header,X-Trace" binding:"required" example:"t-1"`
+		Name  string `json:"name" binding:"required" example:"abc"`
+	}) *response {
+		return nil
+	})
+	cases := map[string]*RequestCase{}
+	for _, c := range api.RequestCases() {
+		cases[c.Name] = c
+	}
+	if c := cases["header 'X-Trace' missing required"]; c == nil || c.Valid || c.Header.Get("X-Trace") != "" {
+		t.Errorf("missing required header case is not generated: %+v", c)
+	}
+	if c := cases["body 'name' missing required"]; c == nil || c.Valid || string(c.Body) != `{}` {
+		t.Errorf("missing required body case is not generated: %+v", c)
+	}
+}
@@ -0,0 +1,140 @@
+package swagger
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"strings"
+	"sync/atomic"
+)
+
+// ResponseValidateMode decides what to do when a response does not match the response schema
+type ResponseValidateMode int32
+
+const (
+	ResponseValidateOff ResponseValidateMode = iota
+	// ResponseValidateLog logs and counts the violations
+	ResponseValidateLog
+	// ResponseValidateCount only counts the violations
+	ResponseValidateCount
+	// ResponseValidateFail counts the violations and replaces the response with a 500 error
+	ResponseValidateFail
+)
+
+// SetResponseValidation enables response validation of all apis in the group, it's meant for test and dev environment
+// as responses are buffered and decoded again. Sub-groups follow it unless they set their own, handlers made by
+// WrapHandler with the group are validated too.
+func (a *ApiGroup) SetResponseValidation(mode ResponseValidateMode) {
+	a.respValidate.Store(int32(mode))
+	a.respValidateSet.Store(true)
+}
+
+// responseValidateMode returns the mode of the group or its nearest parent setting it
+func (a *ApiGroup) responseValidateMode() ResponseValidateMode {
+	for g := a; g != nil; g = g.parent {
+		if g.respValidateSet.Load() {
+			return ResponseValidateMode(g.respValidate.Load())
+		}
+	}
+	return ResponseValidateOff
+}
+
+// ResponseViolations returns violation counts of apis in the group and its sub-groups keyed by "METHOD route"
+func (a *ApiGroup) ResponseViolations() map[string]int64 {
+	res := map[string]int64{}
+	a.respViolations.Range(func(key, value any) bool {
+		api := key.(*Api)
+		res[api.Method+" "+api.Route] = value.(*atomic.Int64).Load()
+		return true
+	})
+	return res
+}
+
+func (a *ApiGroup) countViolation(api *Api) {
+	for g := a; g != nil; g = g.parent {
+		v, _ := g.respViolations.LoadOrStore(api, new(atomic.Int64))
+		v.(*atomic.Int64).Add(1)
+	}
+}
+
+func (a *ApiGroup) responseValidator(api *Api) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		a.validateResponse(c, api, c.Next)
+	}
+}
+
+// validateResponse buffers the response written by next and validates it against the response schema of api
+func (a *ApiGroup) validateResponse(c *gin.Context, api *Api, next func()) {
+	mode := a.responseValidateMode()
+	if mode == ResponseValidateOff || api.ResponseSchema == nil {
+		next()
+		return
+	}
+	w := &bufferedWriter{ResponseWriter: c.Writer, status: 200}
+	c.Writer = w
+	next()
+	c.Writer = w.ResponseWriter
+
+	ct := w.Header().Get("Content-Type")
+	if w.wrote && w.status == 200 && (ct == "" || strings.Contains(ct, "json")) {
+		if err := api.ResponseSchema.ValidateResponse(w.buf.Bytes()); err != nil {
+			a.countViolation(api)
+			switch mode {
+			case ResponseValidateLog:
+				log.Printf("response of %s %s does not match schema: %v", api.Method, api.Route, err)
+			case ResponseValidateFail:
+				abortWithStatusJson(c, 500, &ErrorResponse{Error: fmt.Sprintf("response does not match schema: %v", err)})
+				return
+			}
+		}
+	}
+	if w.wrote {
+		c.Writer.WriteHeader(w.status)
+	}
+	c.Writer.Write(w.buf.Bytes())
+}
+
+// bufferedWriter holds the response until it has been validated
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	wrote  bool
+	buf    bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+		w.wrote = true
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.wrote = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.wrote = true
+	return w.buf.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.wrote = true
+	return w.buf.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.wrote {
+		return -1
+	}
+	return w.buf.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.wrote
+}
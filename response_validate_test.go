@@ -0,0 +1,122 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type orderResponse struct {
+	Id     int      `json:"id" required:"true"`
+	Status string   `json:"status" enum:"paid,closed"`
+	Items  []string `json:"items" binding:"max=2"`
+	Note   string   `json:"note,omitempty"`
+}
+
+func TestValidateResponse(t *testing.T) {
+	sc := generateSchema(reflect.ValueOf(&orderResponse{}), "")
+	cases := []struct {
+		body string
+		err  string
+	}{
+		{`{"id":1,"status":"paid","items":["a"],"note":"x"}`, ""},
+		{`{"status":"paid"}`, "'id' is required"},
+		{`{"id":"1"}`, "'id' should be integer"},
+		{`{"id":1.5}`, "'id' should be integer"},
+		{`{"id":1,"status":"open"}`, "'status' must be oneof 'paid closed'"},
+		{`{"id":1,"items":["a","b","c"]}`, "'items' length should be less or equal than 2"},
+		{`{"id":1,"extra":true}`, "'extra' is not declared in schema"},
+	}
+	for _, c := range cases {
+		err := sc.ValidateResponse([]byte(c.body))
+		msg := ""
+		if err != nil {
+			msg = err.Error()
+		}
+		if msg != c.err {
+			t.Errorf("%s: expect %q, got %q", c.body, c.err, msg)
+		}
+	}
+}
+
+func TestResponseValidation(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	RegisterAPI(group, gine, "GET", "/orders/:id", func(ctx *gin.Context, req *struct {
+		Id int `json:"id" location:"path,id"`
+	}) *orderResponse {
+		return &orderResponse{Id: req.Id, Status: "unknown"}
+	})
+	group.RegisterGin(gine, &struct{}{}, &orderResponse{}, "GET", "/gin/orders", func(ctx *gin.Context) {
+		ctx.JSON(200, gin.H{"id": 1, "status": "paid", "price": 1})
+	})
+
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		gine.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w
+	}
+
+	if w := get("/orders/1"); w.Code != 200 {
+		t.Fatalf("validation should be off by default, got %d", w.Code)
+	}
+
+	group.SetResponseValidation(ResponseValidateCount)
+	if w := get("/orders/1"); w.Code != 200 || !strings.Contains(w.Body.String(), `"status":"unknown"`) {
+		t.Fatalf("count mode should keep response, got %d %s", w.Code, w.Body.String())
+	}
+	if n := group.ResponseViolations()["GET /orders/:id"]; n != 1 {
+		t.Fatalf("expect 1 violation, got %d", n)
+	}
+
+	group.SetResponseValidation(ResponseValidateFail)
+	w := get("/orders/1")
+	if w.Code != 500 || !strings.Contains(w.Body.String(), "'status' must be oneof") {
+		t.Fatalf("fail mode should respond 500, got %d %s", w.Code, w.Body.String())
+	}
+	w = get("/gin/orders")
+	if w.Code != 500 || !strings.Contains(w.Body.String(), "'price' is not declared") {
+		t.Fatalf("fail mode should respond 500, got %d %s", w.Code, w.Body.String())
+	}
+	if n := group.ResponseViolations()["GET /gin/orders"]; n != 1 {
+		t.Fatalf("expect 1 violation, got %d", n)
+	}
+}
+
+func TestWrapHandlerResponseValidation(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	gine.GET("/raw/orders", WrapHandler(group, func(ctx *gin.Context, req *struct{}) *orderResponse {
+		return &orderResponse{Id: 1, Status: "unknown"}
+	}, nil))
+	get := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		gine.ServeHTTP(w, httptest.NewRequest("GET", "/raw/orders", nil))
+		return w
+	}
+	if w := get(); w.Code != 200 {
+		t.Fatalf("validation should be off by default, got %d", w.Code)
+	}
+	group.SetResponseValidation(ResponseValidateFail)
+	if w := get(); w.Code != 500 || !strings.Contains(w.Body.String(), "'status' must be oneof") {
+		t.Fatalf("wrapped handler should be validated, got %d %s", w.Code, w.Body.String())
+	}
+	if n := group.ResponseViolations()["GET /raw/orders"]; n != 1 {
+		t.Fatalf("expect 1 violation, got %d", n)
+	}
+}
+
+func TestValidateResponseBindingRequired(t *testing.T) {
+	sc := generateSchema(reflect.ValueOf(&struct {
+		Id   *int   `json:"id" binding:"required"`
+		Name string `json:"name,omitempty" binding:"omitempty,max=3"`
+	}{}), "")
+	if err := sc.ValidateResponse([]byte(`{}`)); err == nil || err.Error() != "'id' is required" {
+		t.Errorf("binding required field should be required, got %v", err)
+	}
+	if err := sc.ValidateResponse([]byte(`{"id":1}`)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
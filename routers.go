@@ -1,67 +1,131 @@
 package swagger
 
-//type Router struct {
-//	g      gin.IRouter
-//	path   string
-//	method string
-//}
-//
-//func (g *Router) Path() string {
-//	return g.path
-//}
-//
-//func (g *Router) Use(middleware ...gin.HandlerFunc) *Router {
-//	g.g.Use(middleware...)
-//	return g
-//}
-//func (g *Router) Group(pth string, handlers ...gin.HandlerFunc) *Router {
-//	gg := g.g.Group(pth)
-//	gg.Use(handlers...)
-//
-//	return &Router{
-//		g:    gg,
-//		path: path.Join(g.path, pth),
-//	}
-//}
-//
-//func (g *Router) GET(pth string, handlers ...gin.HandlerFunc) *Router {
-//	return g.Handle("GET", pth, handlers...)
-//}
-//func (g *Router) POST(pth string, handlers ...gin.HandlerFunc) *Router {
-//	return g.Handle("POST", pth, handlers...)
-//}
-//
-//func (g *Router) Handle(method, pth string, handlers ...gin.HandlerFunc) *Router {
-//	g.g.Handle(method, pth, handlers...)
-//	return &Router{
-//		g:      g.g,
-//		method: method,
-//		path:   path.Join(g.path, pth),
-//	}
-//}
-
-//func NewRouter(r gin.IRouter) *Router {
-//	return &Router{
-//		g: r,
-//	}
-//}
-//
-//func RegisterAPI[Req, Resp any](r *ApiGroup, router *Router, method, path string, handler Handler[Req, Resp], opts ...OptFunc) {
-//	rsc := generateSchema(reflect.ValueOf(new(Req)), "")
-//	a := &Api{
-//		Request:  new(Req),
-//		Response: new(Resp),
-//
-//		Method:         method,
-//		RequestSchema:  rsc,
-//		ResponseSchema: generateSchema(reflect.ValueOf(new(Resp)), ""),
-//	}
-//	for _, opt := range opts {
-//		opt(a)
-//	}
-//	rsc.Description = a.Description
-//
-//	route := router.Handle(method, path, r.schemaHandler(a), WrapHandler[Req, Resp](handler, a.ErrHandler))
-//	a.Route = route.Path()
-//	r.apis = append(r.apis, a)
-//}
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// GroupOptFunc configures a sub-group created by ApiGroup.Group
+type GroupOptFunc func(g *ApiGroup)
+
+// WithGroupTitle sets the section title of the group in docs, the base path of its router is used by default
+func WithGroupTitle(title string) GroupOptFunc {
+	return func(g *ApiGroup) {
+		g.title = title
+	}
+}
+
+// WithGroupDescription sets the section description of the group in docs
+func WithGroupDescription(desc string) GroupOptFunc {
+	return func(g *ApiGroup) {
+		g.description = desc
+	}
+}
+
+// WithGroupTags overrides the default tags inherited from the parent group
+func WithGroupTags(tags ...string) GroupOptFunc {
+	return func(g *ApiGroup) {
+		g.tags = tags
+	}
+}
+
+// WithGroupErrHandler sets the error handler of apis registered without WithErrHandler
+func WithGroupErrHandler(f func(c *gin.Context, err error)) GroupOptFunc {
+	return func(g *ApiGroup) {
+		g.errHandler = f
+	}
+}
+
+// WithGroupSecurity overrides the default security schemes inherited from the parent group
+func WithGroupSecurity(schemes ...*SecurityScheme) GroupOptFunc {
+	return func(g *ApiGroup) {
+		g.security = schemes
+	}
+}
+
+// WithGroupMiddleware appends middlewares run before every api of the group after the ones inherited,
+// they run before the middlewares of WithMiddleware
+func WithGroupMiddleware(handlers ...gin.HandlerFunc) GroupOptFunc {
+	return func(g *ApiGroup) {
+		g.middleware = append(g.middleware, handlers...)
+	}
+}
+
+// WithGroupCommonParams overrides the common params inherited from the parent group, see SetCommonParams
+func WithGroupCommonParams[T any]() GroupOptFunc {
+	return func(g *ApiGroup) {
+		SetCommonParams[T](g)
+	}
+}
+
+// Group creates a sub-group registering apis on router, like gin groups it inherits the settings of the parent
+// at creation: tags, error handler, security, common params, versions, middlewares and interceptors.
+// The authorizer, audience resolver, mock mode, response validation and deprecated call hook are resolved
+// through the parents at request time, so they can be set on the group or any parent, before or after.
+// Apis registered in the sub-group are part of the parent's apis and documented in a section of the group.
+func (a *ApiGroup) Group(router BasicRouter, opts ...GroupOptFunc) *ApiGroup {
+	g := &ApiGroup{
+		parent:     a,
+		router:     router,
+		title:      router.BasePath(),
+		vad:        a.vad,
+		tags:       a.tags,
+		errHandler: a.errHandler,
+		security:   a.security,
+		common:     a.common,
+		middleware: append([]gin.HandlerFunc{}, a.middleware...),
+
+		interceptors: append([]GroupInterceptor{}, a.interceptors...),
+		version:      a.version,
+		versioning:   a.versioning,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	a.children = append(a.children, g)
+	return g
+}
+
+// Router returns the router of the group created by Group, nil for groups created by NewAPIGroup
+func (a *ApiGroup) Router() BasicRouter {
+	return a.router
+}
+
+// Groups returns the sub-groups created by Group
+func (a *ApiGroup) Groups() []*ApiGroup {
+	return a.children
+}
+
+// rootGroup returns the top group, it owns the version routes of all sub-groups
+func (a *ApiGroup) rootGroup() *ApiGroup {
+	for a.parent != nil {
+		a = a.parent
+	}
+	return a
+}
+
+// add appends api to the group and all its parents
+func (a *ApiGroup) add(api *Api) {
+	api.group = a
+	for g := a; g != nil; g = g.parent {
+		g.apis = append(g.apis, api)
+	}
+}
+
+// applyDefaults sets the group settings on an api registered without them
+func (a *ApiGroup) applyDefaults(api *Api) {
+	if len(api.Tags) == 0 && len(a.tags) > 0 {
+		api.Tags = append([]string{}, a.tags...)
+	}
+	if api.ErrHandler == nil && a.errHandler != nil {
+		api.ErrHandler = a.errHandler
+	}
+	if api.Security == nil && len(a.security) > 0 {
+		api.Security = append([]*SecurityScheme{}, a.security...)
+	}
+	if a.common != nil && api.RequestSchema != nil {
+		api.RequestSchema = a.common.mergeInto(api.RequestSchema)
+	}
+	if len(api.Versions) == 0 && a.version != "" {
+		api.Versions = []string{a.version}
+	}
+}
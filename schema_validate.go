@@ -0,0 +1,330 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type SchemaError struct {
+	Path    string
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("'%s' %s", e.Path, e.Message)
+}
+
+type SchemaErrors []*SchemaError
+
+func (es SchemaErrors) Error() string {
+	ss := make([]string, len(es))
+	for i, e := range es {
+		ss[i] = e.Error()
+	}
+	return strings.Join(ss, ",")
+}
+
+// ValidateValue checks a json decoded value against the schema, only json fields of objects are checked.
+func (s *Schema) ValidateValue(v any) error {
+	c := &schemaChecker{}
+	c.check(s, "", v)
+	return c.err()
+}
+
+// ValidateResponse checks a json response body against the schema,
+// fields not declared in the schema are reported as well as type, required, enum and binding constraint violations.
+func (s *Schema) ValidateResponse(body []byte) error {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("unmarshal response as json error: %w", err)
+	}
+	c := &schemaChecker{strict: true}
+	c.check(s, "", v)
+	return c.err()
+}
+
+// schemaChecker collects errors of a value walking through the schema,
+// undeclared object fields are reported if strict is set.
+type schemaChecker struct {
+	errs   SchemaErrors
+	strict bool
+}
+
+func (c *schemaChecker) fail(pth string, format string, args ...any) {
+	c.errs = append(c.errs, &SchemaError{Path: pth, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *schemaChecker) err() error {
+	if len(c.errs) > 0 {
+		return c.errs
+	}
+	return nil
+}
+
+// jsonFieldName strips options of json tag, e.g. "name,omitempty"
+func jsonFieldName(name string) string {
+	name, _, _ = strings.Cut(name, ",")
+	return name
+}
+
+func (c *schemaChecker) check(s *Schema, pth string, v any) {
+	if v == nil {
+		return
+	}
+	fail := func(format string, args ...any) {
+		c.fail(pth, format, args...)
+	}
+	switch s.Type {
+	case "object":
+		m, ok := v.(map[string]any)
+		if !ok {
+			fail("should be object")
+			return
+		}
+		prefix := pth
+		if prefix != "" {
+			prefix += "."
+		}
+		declared := map[string]bool{}
+		for _, name := range sortedProperties(s) {
+			p := s.Properties[name]
+			if !isBodyField(p) {
+				continue
+			}
+			name = jsonFieldName(name)
+			if name == "-" {
+				continue
+			}
+			declared[name] = true
+			pv, ok := m[name]
+			if !ok || pv == nil {
+				if p.isRequired() {
+					c.fail(prefix+name, "is required")
+				}
+				continue
+			}
+			c.check(p, prefix+name, pv)
+		}
+		if c.strict && s.Properties != nil {
+			keys := make([]string, 0, len(m))
+			for k := range m {
+				if !declared[k] {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				c.fail(prefix+k, "is not declared in schema")
+			}
+		}
+	case "array":
+		items, ok := v.([]any)
+		if !ok {
+			fail("should be array")
+			return
+		}
+		c.checkBinding(s, pth, float64(len(items)), "length")
+		if s.Items != nil {
+			for i, item := range items {
+				c.check(s.Items, fmt.Sprintf("%s[%d]", pth, i), item)
+			}
+		}
+	case "integer", "number":
+		var f float64
+		switch n := v.(type) {
+		case float64:
+			f = n
+		case json.Number:
+			var err error
+			if f, err = n.Float64(); err != nil {
+				fail("should be %s", s.Type)
+				return
+			}
+		default:
+			fail("should be %s", s.Type)
+			return
+		}
+		if s.Type == "integer" && f != float64(int64(f)) {
+			fail("should be integer")
+			return
+		}
+		s.validateEnum(strconv.FormatFloat(f, 'f', -1, 64), fail)
+		c.checkBinding(s, pth, f, "value")
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			fail("should be string")
+			return
+		}
+		if s.MaxLength != nil && len(str) > *s.MaxLength {
+			fail("length should be less or equal than %d", *s.MaxLength)
+		}
+		s.validateEnum(str, fail)
+		c.checkBinding(s, pth, float64(utf8.RuneCountInString(str)), "length")
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			fail("should be boolean")
+		}
+	}
+}
+
+// bindingRules parses the binding tag, e.g. "required,min=1" into [["required",""],["min","1"]]
+func bindingRules(binding string) [][2]string {
+	rules := [][2]string{}
+	for _, r := range strings.Split(binding, ",") {
+		if r == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(r, "=")
+		rules = append(rules, [2]string{k, v})
+	}
+	return rules
+}
+
+// isRequired reports whether the field is required by tag `required:"true"` or a required binding rule
+func (s *Schema) isRequired() bool {
+	return s.Required || requiredByBinding(s.Binding)
+}
+
+// requiredByBinding reports whether binding has a required rule, rules after dive apply to elements
+func requiredByBinding(binding string) bool {
+	for _, rule := range bindingRules(binding) {
+		switch rule[0] {
+		case "required":
+			return true
+		case "dive":
+			return false
+		}
+	}
+	return false
+}
+
+// checkBinding checks min, max, len, gt, gte, lt and lte rules of binding tag,
+// n is the value of numbers or the length of strings and arrays.
+func (c *schemaChecker) checkBinding(s *Schema, pth string, n float64, what string) {
+	if s.Binding == "" {
+		return
+	}
+	for _, rule := range bindingRules(s.Binding) {
+		k, v := rule[0], rule[1]
+		limit, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			continue
+		}
+		switch k {
+		case "min", "gte":
+			if n < limit {
+				c.fail(pth, "%s should be greater or equal than %s", what, v)
+			}
+		case "max", "lte":
+			if n > limit {
+				c.fail(pth, "%s should be less or equal than %s", what, v)
+			}
+		case "gt":
+			if n <= limit {
+				c.fail(pth, "%s should be greater than %s", what, v)
+			}
+		case "lt":
+			if n >= limit {
+				c.fail(pth, "%s should be less than %s", what, v)
+			}
+		case "len":
+			if n != limit {
+				c.fail(pth, "%s should be %s", what, v)
+			}
+		}
+	}
+}
+
+func (s *Schema) validateEnum(v string, fail func(format string, args ...any)) {
+	if len(s.Enum) == 0 {
+		return
+	}
+	for _, e := range s.Enum {
+		if e == v {
+			return
+		}
+	}
+	fail("must be oneof '%s'", strings.Join(s.Enum, " "))
+}
+
+// checkParam checks a path, query or header value
+func (c *schemaChecker) checkParam(s *Schema, name string, val string) {
+	if val == "" {
+		if s.isRequired() {
+			c.fail(name, "is required")
+		}
+		return
+	}
+	var v any = val
+	switch s.Type {
+	case "integer", "number":
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			c.fail(name, "should be %s", s.Type)
+			return
+		}
+		v = f
+	case "boolean":
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			c.fail(name, "should be boolean")
+			return
+		}
+		v = b
+	case "array", "object", "any":
+		return
+	}
+	c.check(s, name, v)
+}
+
+// validateRequest checks path, query, header and json body of the request against the request schema,
+// it is used when the go request type is not available, e.g. apis loaded from a schema dump.
+func (s *Schema) validateRequest(ctx *gin.Context) error {
+	c := &schemaChecker{}
+	for _, p := range collectParams(s) {
+		var val string
+		switch p.schema.Location {
+		case "path":
+			val = ctx.Param(p.name)
+		case "query":
+			val = ctx.Query(p.name)
+		case "header":
+			val = ctx.GetHeader(p.name)
+		}
+		if val == "" {
+			val = p.schema.Default
+		}
+		c.checkParam(p.schema, p.name, val)
+	}
+
+	data, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return err
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(data))
+	if len(data) > 0 || ctx.ContentType() == "application/json" {
+		var body any
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err = dec.Decode(&body); err != nil {
+			return fmt.Errorf("unmarshal body as json error: %w", err)
+		}
+		c.check(s, "", body)
+	} else {
+		c.check(s, "", map[string]any{})
+	}
+	return c.err()
+}
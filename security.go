@@ -0,0 +1,158 @@
+package swagger
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+const (
+	SecurityTypeHttp   = "http"
+	SecurityTypeApiKey = "apiKey"
+)
+
+const principalKey = "swagger.principal"
+
+// Verifier checks the credential of a request and returns the authenticated principal
+type Verifier func(c *gin.Context, credential string) (any, error)
+
+// SecurityScheme describes how an api authenticates requests, Name identifies the scheme in docs and specs.
+// Schemes without Verify are documented only.
+type SecurityScheme struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	// In and Key locate the api key, In is one of header, query and cookie
+	In          string   `json:"in,omitempty"`
+	Key         string   `json:"key,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Verify      Verifier `json:"-"`
+}
+
+// BearerAuth authenticates by JWT in header "Authorization: Bearer <token>", the token is passed to verify
+func BearerAuth(name string, verify Verifier) *SecurityScheme {
+	return &SecurityScheme{Name: name, Type: SecurityTypeHttp, Scheme: "bearer", BearerFormat: "JWT", Verify: verify}
+}
+
+// ApiKeyAuth authenticates by the api key named key in header, query or cookie
+func ApiKeyAuth(name, in, key string, verify Verifier) *SecurityScheme {
+	return &SecurityScheme{Name: name, Type: SecurityTypeApiKey, In: in, Key: key, Verify: verify}
+}
+
+// BasicAuth authenticates by http basic auth
+func BasicAuth(name string, verify func(c *gin.Context, user, password string) (any, error)) *SecurityScheme {
+	s := &SecurityScheme{Name: name, Type: SecurityTypeHttp, Scheme: "basic"}
+	if verify != nil {
+		s.Verify = func(c *gin.Context, credential string) (any, error) {
+			user, password, _ := strings.Cut(credential, ":")
+			return verify(c, user, password)
+		}
+	}
+	return s
+}
+
+// Summary describes where the credential is carried, e.g. "Bearer JWT (header Authorization)"
+func (s *SecurityScheme) Summary() string {
+	switch {
+	case s.Type == SecurityTypeApiKey:
+		return fmt.Sprintf("API Key (%s %s)", s.In, s.Key)
+	case strings.EqualFold(s.Scheme, "bearer"):
+		return strings.TrimSpace("Bearer "+s.BearerFormat) + " (header Authorization)"
+	case strings.EqualFold(s.Scheme, "basic"):
+		return "Basic (header Authorization)"
+	}
+	return s.Type + " " + s.Scheme
+}
+
+// credential returns the credential of request, empty if missing
+func (s *SecurityScheme) credential(c *gin.Context) string {
+	if s.Type == SecurityTypeApiKey {
+		switch s.In {
+		case "query":
+			return c.Query(s.Key)
+		case "cookie":
+			v, _ := c.Cookie(s.Key)
+			return v
+		}
+		return c.GetHeader(s.Key)
+	}
+	scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, s.Scheme) {
+		return ""
+	}
+	if strings.EqualFold(s.Scheme, "basic") {
+		bs, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return ""
+		}
+		return string(bs)
+	}
+	return token
+}
+
+// WithSecurity sets the schemes accepted by the api, any of them authenticates a request.
+// WithSecurity() without schemes makes the api public regardless of SetDefaultSecurity.
+func WithSecurity(schemes ...*SecurityScheme) OptFunc {
+	return func(o *Api) {
+		o.Security = append([]*SecurityScheme{}, schemes...)
+	}
+}
+
+// SetDefaultSecurity sets schemes of apis registered later without WithSecurity
+func (a *ApiGroup) SetDefaultSecurity(schemes ...*SecurityScheme) {
+	a.security = schemes
+}
+
+// Principal returns the principal returned by the verifier of the request
+func Principal(c *gin.Context) any {
+	v, _ := c.Get(principalKey)
+	return v
+}
+
+// PrincipalAs returns the principal of the request as T
+func PrincipalAs[T any](c *gin.Context) (T, bool) {
+	v, ok := Principal(c).(T)
+	return v, ok
+}
+
+var errMissingCredential = errors.New("missing credential")
+
+// securityHandler verifies requests before binding, the first scheme accepting the request sets the principal
+func (a *ApiGroup) securityHandler(api *Api) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var err error
+		enforced := false
+		for _, s := range api.Security {
+			if s.Verify == nil {
+				continue
+			}
+			enforced = true
+			cred := s.credential(c)
+			if cred == "" {
+				err = errMissingCredential
+				continue
+			}
+			var principal any
+			principal, err = s.Verify(c, cred)
+			if err == nil {
+				c.Set(principalKey, principal)
+				c.Next()
+				return
+			}
+		}
+		if !enforced {
+			c.Next()
+			return
+		}
+		for _, s := range api.Security {
+			if s.Type == SecurityTypeHttp && s.Scheme != "" {
+				c.Header("WWW-Authenticate", strings.ToUpper(s.Scheme[:1])+s.Scheme[1:])
+				break
+			}
+		}
+		abortWithStatusJson(c, 401, &ErrorResponse{Error: "unauthorized: " + err.Error()})
+	}
+}
@@ -0,0 +1,106 @@
+package swagger
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type securityUser struct {
+	Name string
+}
+
+type securityRequest struct {
+	Id int `json:"id" binding:"required"`
+}
+
+type securityResponse struct {
+	User string `json:"user"`
+}
+
+func TestSecurity(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	bearer := BearerAuth("bearerAuth", func(c *gin.Context, token string) (any, error) {
+		if token != "t1" {
+			return nil, errors.New("invalid token")
+		}
+		return &securityUser{Name: "alice"}, nil
+	})
+	apiKey := ApiKeyAuth("apiKey", "query", "api_key", func(c *gin.Context, key string) (any, error) {
+		if key != "k1" {
+			return nil, errors.New("invalid api key")
+		}
+		return &securityUser{Name: "service"}, nil
+	})
+	basic := BasicAuth("basicAuth", func(c *gin.Context, user, password string) (any, error) {
+		if password != "secret" {
+			return nil, errors.New("invalid password")
+		}
+		return &securityUser{Name: user}, nil
+	})
+	group.SetDefaultSecurity(bearer, apiKey)
+	handler := func(ctx *gin.Context, req *securityRequest) *securityResponse {
+		u, _ := PrincipalAs[*securityUser](ctx)
+		return &securityResponse{User: u.Name}
+	}
+	RegisterAPI(group, gine, "POST", "/orders", handler, WithTitle("create order"))
+	RegisterAPI(group, gine, "POST", "/admin", handler, WithTitle("admin"), WithSecurity(basic))
+	RegisterAPI(group, gine, "POST", "/login", func(ctx *gin.Context, req *struct{}) *securityResponse {
+		return &securityResponse{}
+	}, WithTitle("login"), WithSecurity())
+
+	do := func(url string, set func(r *http.Request)) (int, string, string) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", url, strings.NewReader(`{}`))
+		if set != nil {
+			set(r)
+		}
+		gine.ServeHTTP(w, r)
+		return w.Code, w.Body.String(), w.Header().Get("WWW-Authenticate")
+	}
+	if code, body, auth := do("/orders", nil); code != 401 || !strings.Contains(body, "missing credential") || auth != "Bearer" {
+		t.Errorf("unexpected response without credential: %d %s %q", code, body, auth)
+	}
+	if code, body, _ := do("/orders", func(r *http.Request) { r.Header.Set("Authorization", "Bearer bad") }); code != 401 {
+		t.Errorf("invalid token should be rejected: %d %s", code, body)
+	}
+	// verifiers run before binding, so a valid credential reaches the binding error
+	if code, body, _ := do("/orders", func(r *http.Request) { r.Header.Set("Authorization", "Bearer t1") }); code != 400 {
+		t.Errorf("authorized request should reach binding: %d %s", code, body)
+	}
+	w := httptest.NewRecorder()
+	gine.ServeHTTP(w, httptest.NewRequest("POST", "/orders?api_key=k1", strings.NewReader(`{"id":1}`)))
+	if w.Code != 200 || !strings.Contains(w.Body.String(), `"service"`) {
+		t.Errorf("api key should authenticate: %d %s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/admin", strings.NewReader(`{"id":1}`))
+	r.SetBasicAuth("root", "secret")
+	gine.ServeHTTP(w, r)
+	if w.Code != 200 || !strings.Contains(w.Body.String(), `"root"`) {
+		t.Errorf("basic auth should authenticate: %d %s", w.Code, w.Body.String())
+	}
+	if code, _, _ := do("/login", nil); code != 200 {
+		t.Errorf("login should be public, got %d", code)
+	}
+
+	md := group.GenerateMarkdown()
+	if !strings.Contains(md, "**鉴权方式**: `bearerAuth` Bearer JWT (header Authorization) 或 `apiKey` API Key (query api_key)") ||
+		!strings.Contains(md, "`basicAuth` Basic (header Authorization)") {
+		t.Errorf("markdown should show security requirements:\n%s", md)
+	}
+	spec := group.GenerateOpenAPI("api", "1.0.0")
+	if s := spec.Components.SecuritySchemes["apiKey"]; s == nil || s.In != "query" || s.Name != "api_key" {
+		t.Errorf("unexpected api key scheme: %v", s)
+	}
+	if op := spec.Paths["/orders"]["post"]; len(op.Security) != 2 || op.Responses["401"] == nil {
+		t.Errorf("unexpected operation security: %v", op.Security)
+	}
+	if op := spec.Paths["/login"]["post"]; len(op.Security) != 0 {
+		t.Error("login should have no security requirement")
+	}
+}
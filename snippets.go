@@ -0,0 +1,206 @@
+package swagger
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExampleBaseUrl is the server address used by generated request snippets and exports
+var ExampleBaseUrl = "http://localhost:8080"
+
+type Snippet struct {
+	Lang  string
+	Label string
+	Code  string
+}
+
+// apiExample is a request built from the example values of request schema
+type apiExample struct {
+	Method  string
+	Url     string
+	Headers [][2]string
+	Body    string
+}
+
+func (a *Api) example(baseUrl string) *apiExample {
+	ex := &apiExample{
+		Method: a.Method,
+		Url:    baseUrl + a.RequestSchema.generateExamplePath(a.Route),
+	}
+	if query := strings.Join(a.RequestSchema.genExampleQuery(), "&"); query != "" {
+		ex.Url += "?" + query
+	}
+	for _, h := range a.RequestSchema.genExampleHeader() {
+		k, v, _ := strings.Cut(h, ": ")
+		ex.Headers = append(ex.Headers, [2]string{k, v})
+	}
+	if body := a.RequestSchema.GenExampleJson(); body != "{}" {
+		ex.Body = body
+		ex.Headers = append(ex.Headers, [2]string{"Content-Type", "application/json"})
+	}
+	return ex
+}
+
+// ExampleRequest builds a http request from the example values of request schema
+func (a *Api) ExampleRequest(baseUrl string) (*http.Request, error) {
+	ex := a.example(baseUrl)
+	req, err := http.NewRequest(ex.Method, ex.Url, strings.NewReader(ex.Body))
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range ex.Headers {
+		req.Header.Add(h[0], h[1])
+	}
+	return req, nil
+}
+
+// Snippets returns runnable request examples in curl, httpie, go, javascript and python
+func (a *Api) Snippets(baseUrl string) []*Snippet {
+	ex := a.example(baseUrl)
+	return []*Snippet{
+		{Lang: "shell", Label: "cURL", Code: ex.curl()},
+		{Lang: "shell", Label: "HTTPie", Code: ex.httpie()},
+		{Lang: "go", Label: "Go", Code: ex.golang()},
+		{Lang: "javascript", Label: "JavaScript", Code: ex.fetch()},
+		{Lang: "python", Label: "Python", Code: ex.python(a.RequestSchema.GenExample())},
+	}
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func (e *apiExample) curl() string {
+	lines := []string{"curl -X " + e.Method + " " + shellQuote(e.Url)}
+	for _, h := range e.Headers {
+		lines = append(lines, "  -H "+shellQuote(h[0]+": "+h[1]))
+	}
+	if e.Body != "" {
+		lines = append(lines, "  -d "+shellQuote(e.Body))
+	}
+	return strings.Join(lines, " \\\n")
+}
+
+func (e *apiExample) httpie() string {
+	sb := strings.Builder{}
+	if e.Body != "" {
+		sb.WriteString("echo " + shellQuote(e.Body) + " | \\\n  ")
+	}
+	sb.WriteString("http " + e.Method + " " + shellQuote(e.Url))
+	for _, h := range e.Headers {
+		sb.WriteString(" \\\n  " + shellQuote(h[0]+":"+h[1]))
+	}
+	return sb.String()
+}
+
+func (e *apiExample) golang() string {
+	sb := strings.Builder{}
+	body := "nil"
+	if e.Body != "" {
+		lit := "`" + e.Body + "`"
+		if strings.Contains(e.Body, "`") {
+			lit = strconv.Quote(e.Body)
+		}
+		sb.WriteString("body := strings.NewReader(" + lit + ")\n")
+		body = "body"
+	}
+	sb.WriteString(fmt.Sprintf("req, err := http.NewRequest(%q, %q, %s)\n", e.Method, e.Url, body))
+	sb.WriteString("if err != nil {\n\tpanic(err)\n}\n")
+	for _, h := range e.Headers {
+		sb.WriteString(fmt.Sprintf("req.Header.Set(%q, %q)\n", h[0], h[1]))
+	}
+	sb.WriteString("resp, err := http.DefaultClient.Do(req)\n")
+	sb.WriteString("if err != nil {\n\tpanic(err)\n}\n")
+	sb.WriteString("defer resp.Body.Close()\n")
+	sb.WriteString("data, _ := io.ReadAll(resp.Body)\n")
+	sb.WriteString("fmt.Println(resp.Status, string(data))")
+	return sb.String()
+}
+
+func (e *apiExample) fetch() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("const resp = await fetch(%s, {\n", strconv.Quote(e.Url)))
+	sb.WriteString(fmt.Sprintf("  method: %q,\n", e.Method))
+	if len(e.Headers) > 0 {
+		sb.WriteString("  headers: {\n")
+		for _, h := range e.Headers {
+			sb.WriteString(fmt.Sprintf("    %q: %q,\n", h[0], h[1]))
+		}
+		sb.WriteString("  },\n")
+	}
+	if e.Body != "" {
+		sb.WriteString("  body: JSON.stringify(" + strings.ReplaceAll(e.Body, "\n", "\n  ") + "),\n")
+	}
+	sb.WriteString("});\n")
+	sb.WriteString("console.log(resp.status, await resp.text());")
+	return sb.String()
+}
+
+func (e *apiExample) python(body any) string {
+	sb := strings.Builder{}
+	sb.WriteString("import requests\n\n")
+	sb.WriteString(fmt.Sprintf("resp = requests.request(\n    %q,\n    %q,\n", e.Method, e.Url))
+	headers := [][2]string{}
+	for _, h := range e.Headers {
+		if e.Body != "" && h[0] == "Content-Type" {
+			// set by requests when json is passed
+			continue
+		}
+		headers = append(headers, h)
+	}
+	if len(headers) > 0 {
+		sb.WriteString("    headers={\n")
+		for _, h := range headers {
+			sb.WriteString(fmt.Sprintf("        %q: %q,\n", h[0], h[1]))
+		}
+		sb.WriteString("    },\n")
+	}
+	if e.Body != "" {
+		sb.WriteString("    json=" + pyLiteral(body, 1) + ",\n")
+	}
+	sb.WriteString(")\n")
+	sb.WriteString("print(resp.status_code, resp.text)")
+	return sb.String()
+}
+
+// pyLiteral formats example value generated by Schema.GenExample as python literal
+func pyLiteral(v any, indent int) string {
+	ind := strings.Repeat("    ", indent)
+	switch vv := v.(type) {
+	case nil:
+		return "None"
+	case bool:
+		return pyBool(vv)
+	case string:
+		return strconv.Quote(vv)
+	case map[string]any:
+		if len(vv) == 0 {
+			return "{}"
+		}
+		keys := make([]string, 0, len(vv))
+		for k := range vv {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		sb := strings.Builder{}
+		sb.WriteString("{\n")
+		for _, k := range keys {
+			sb.WriteString(ind + "    " + strconv.Quote(k) + ": " + pyLiteral(vv[k], indent+1) + ",\n")
+		}
+		sb.WriteString(ind + "}")
+		return sb.String()
+	case []any:
+		if len(vv) == 0 {
+			return "[]"
+		}
+		items := make([]string, len(vv))
+		for i, item := range vv {
+			items[i] = pyLiteral(item, indent)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,29 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"strings"
+	"testing"
+)
+
+func TestSnippets(t *testing.T) {
+	group := NewAPIGroup()
+	RegisterAPI(group, gin.New(), "POST", "/hello/:key/*path", HandlerReq, WithTitle("hello"))
+
+	snippets := group.apis[0].Snippets("http://127.0.0.1")
+	if len(snippets) != 5 {
+		t.Fatalf("expect 5 snippets, got %d", len(snippets))
+	}
+	if !strings.HasPrefix(snippets[0].Code, "curl -X POST 'http://127.0.0.1/hello/test_key/abc/ttx?def=defs' \\\n  -H 'Content-Type: application/json'") {
+		t.Fatalf("unexpected curl snippet:\n%s", snippets[0].Code)
+	}
+	if !strings.Contains(snippets[4].Code, `"mod": "read",`) {
+		t.Fatalf("unexpected python snippet:\n%s", snippets[4].Code)
+	}
+	if md := group.GenerateMarkdown(); !strings.Contains(md, "````python\nimport requests") {
+		t.Fatal("snippets are not rendered in markdown")
+	}
+	if html := group.GenerateHtml(); !strings.Contains(html, `onclick="showSnippet(this, 4)">Python</button>`) {
+		t.Fatal("snippet tabs are not rendered in html")
+	}
+}
@@ -0,0 +1,95 @@
+// Package swaggertest runs contract tests of a swagger.ApiGroup in process
+package swaggertest
+
+import (
+	"fmt"
+	"github.com/seeadoog/swagger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type options struct {
+	status  map[string]int
+	skip    func(api *swagger.Api) bool
+	prepare func(api *swagger.Api, req *http.Request)
+}
+
+type Option func(o *options)
+
+// WithStatus sets the expected status of an api, 200 is expected by default
+func WithStatus(method, route string, status int) Option {
+	return func(o *options) {
+		o.status[method+" "+route] = status
+	}
+}
+
+// WithSkip skips apis that f returns true
+func WithSkip(f func(api *swagger.Api) bool) Option {
+	return func(o *options) {
+		o.skip = f
+	}
+}
+
+// WithPrepare modifies the example request before it is sent, e.g. adding credentials
+func WithPrepare(f func(api *swagger.Api, req *http.Request)) Option {
+	return func(o *options) {
+		o.prepare = f
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{status: map[string]int{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Run sends the example request of every api in the group to handler,
+// and checks the status and the response against the response schema, each api is reported as a subtest.
+func Run(t *testing.T, group *swagger.ApiGroup, handler http.Handler, opts ...Option) {
+	o := newOptions(opts)
+	for _, api := range group.Apis() {
+		api := api
+		t.Run(api.Method+" "+api.Route, func(t *testing.T) {
+			if o.skip != nil && o.skip(api) {
+				t.Skip("skipped by option")
+			}
+			if err := o.check(api, handler); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// Check sends the example request of api to handler and checks the response
+func Check(api *swagger.Api, handler http.Handler, opts ...Option) error {
+	return newOptions(opts).check(api, handler)
+}
+
+func (o *options) check(api *swagger.Api, handler http.Handler) error {
+	req, err := api.ExampleRequest("")
+	if err != nil {
+		return fmt.Errorf("build example request error: %w", err)
+	}
+	if o.prepare != nil {
+		o.prepare(api, req)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	status, ok := o.status[api.Method+" "+api.Route]
+	if !ok {
+		status = 200
+	}
+	if w.Code != status {
+		return fmt.Errorf("%s %s: expect status %d, got %d: %s", req.Method, req.URL, status, w.Code, w.Body.String())
+	}
+	if w.Code == 200 && api.ResponseSchema != nil {
+		if err = api.ResponseSchema.ValidateResponse(w.Body.Bytes()); err != nil {
+			return fmt.Errorf("%s %s: response does not match schema: %w", req.Method, req.URL, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package swaggertest
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/seeadoog/swagger"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type getUserRequest struct {
+	Id    int    `json:"id" location:"path,id" example:"7"`
+	Token string `json:"token" location:"header,X-Token" example:"abc" binding:"required"`
+}
+
+type createUserRequest struct {
+	Name string `json:"name" example:"user01" binding:"required"`
+}
+
+type userResponse struct {
+	Id   int    `json:"id" example:"7"`
+	Name string `json:"name" example:"user01"`
+	Role string `json:"role" enum:"admin,guest" example:"guest"`
+}
+
+func newTestServer(role string) (*swagger.ApiGroup, *gin.Engine) {
+	gine := gin.New()
+	group := swagger.NewAPIGroup()
+	users := gine.Group("/users")
+	swagger.RegisterAPI(group, users, "GET", "/:id", func(ctx *gin.Context, req *getUserRequest) *userResponse {
+		return &userResponse{Id: req.Id, Name: "user01", Role: role}
+	})
+	swagger.RegisterAPI(group, users, "POST", "", func(ctx *gin.Context, req *createUserRequest) *userResponse {
+		return &userResponse{Name: req.Name, Role: role}
+	})
+	return group, gine
+}
+
+func TestRun(t *testing.T) {
+	group, gine := newTestServer("admin")
+	Run(t, group, gine)
+}
+
+func TestCheck(t *testing.T) {
+	group, gine := newTestServer("root")
+	apis := group.Apis()
+
+	err := Check(apis[0], gine)
+	if err == nil || !strings.Contains(err.Error(), "'role' must be oneof") {
+		t.Fatalf("expect response schema error, got %v", err)
+	}
+	err = Check(apis[1], gine, WithStatus("POST", "/users", 201))
+	if err == nil || !strings.Contains(err.Error(), "expect status 201, got 200") {
+		t.Fatalf("expect status error, got %v", err)
+	}
+	err = Check(apis[1], gine, WithStatus("POST", "/users", 400), WithPrepare(func(api *swagger.Api, req *http.Request) {
+		req.Header.Del("Content-Type")
+		req.Body = http.NoBody
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,54 @@
+package swaggertest
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/seeadoog/swagger"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// UpdateGoldenEnv rewrites golden snapshots when set to a non empty value, e.g. SWAGGER_UPDATE_GOLDEN=1 go test ./...
+const UpdateGoldenEnv = "SWAGGER_UPDATE_GOLDEN"
+
+// Golden compares the apis of group with the snapshot committed at file and fails on breaking changes,
+// non-breaking changes are logged. The snapshot has the format of HandlerAllApiSchemas,
+// it is created if missing and rewritten if UpdateGoldenEnv is set.
+func Golden(t *testing.T, group *swagger.ApiGroup, file string) {
+	t.Helper()
+	data, err := json.MarshalIndent(group.Apis(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) || os.Getenv(UpdateGoldenEnv) != "" {
+		if err = os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("golden snapshot %s is written", file)
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := swagger.DiffApiSchemas(old, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range diff.Changes {
+		if c.Breaking {
+			t.Error(c)
+		} else {
+			t.Log(c)
+		}
+	}
+	if !diff.HasBreaking() && len(diff.Changes) > 0 {
+		t.Logf("api is changed compatibly, run with %s=1 to update %s", UpdateGoldenEnv, file)
+	}
+}
@@ -0,0 +1,17 @@
+package swaggertest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "testdata", "api.json")
+	group, _ := newTestServer("admin")
+	Golden(t, group, file)
+	if _, err := os.Stat(file); err != nil {
+		t.Fatalf("golden snapshot is not written: %v", err)
+	}
+	Golden(t, group, file)
+}
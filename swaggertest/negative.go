@@ -0,0 +1,87 @@
+package swaggertest
+
+import (
+	"fmt"
+	"github.com/seeadoog/swagger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// RunNegative sends the invalid request cases of every api in the group to handler
+// and checks that each of them is rejected with 4xx, each api is reported as a subtest.
+func RunNegative(t *testing.T, group *swagger.ApiGroup, handler http.Handler, opts ...Option) {
+	o := newOptions(opts)
+	for _, api := range group.Apis() {
+		api := api
+		t.Run(api.Method+" "+api.Route, func(t *testing.T) {
+			if o.skip != nil && o.skip(api) {
+				t.Skip("skipped by option")
+			}
+			for _, err := range o.checkNegative(api, handler) {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// CheckNegative returns an error for each invalid request case of api which is not rejected with 4xx
+func CheckNegative(api *swagger.Api, handler http.Handler, opts ...Option) []error {
+	return newOptions(opts).checkNegative(api, handler)
+}
+
+func (o *options) send(api *swagger.Api, c *swagger.RequestCase, handler http.Handler) (*httptest.ResponseRecorder, error) {
+	req, err := c.NewRequest("")
+	if err != nil {
+		return nil, err
+	}
+	if o.prepare != nil {
+		o.prepare(api, req)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w, nil
+}
+
+func (o *options) checkNegative(api *swagger.Api, handler http.Handler) []error {
+	errs := []error{}
+	for _, c := range api.RequestCases() {
+		if c.Valid {
+			continue
+		}
+		w, err := o.send(api, c, handler)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: build request error: %w", c.Name, err))
+			continue
+		}
+		if w.Code < 400 || w.Code >= 500 {
+			errs = append(errs, fmt.Errorf("%s: expect 4xx, got %d: %s %s %s", c.Name, w.Code, c.Method, c.Url, c.Body))
+		}
+	}
+	return errs
+}
+
+// Fuzz seeds the corpus with the bodies of request cases of api and fuzzes the request body,
+// the handler must not respond 5xx.
+//
+//	func FuzzCreateUser(f *testing.F) {
+//		swaggertest.Fuzz(f, api, engine)
+//	}
+func Fuzz(f *testing.F, api *swagger.Api, handler http.Handler, opts ...Option) {
+	o := newOptions(opts)
+	cases := api.RequestCases()
+	for _, c := range cases {
+		f.Add(c.Body)
+	}
+	f.Fuzz(func(t *testing.T, body []byte) {
+		c := *cases[0]
+		c.Body = body
+		w, err := o.send(api, &c, handler)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w.Code >= 500 {
+			t.Errorf("%s %s %q: got %d: %s", c.Method, c.Url, body, w.Code, w.Body.String())
+		}
+	})
+}
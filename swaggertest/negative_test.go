@@ -0,0 +1,49 @@
+package swaggertest
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/seeadoog/swagger"
+	"strings"
+	"testing"
+)
+
+type orderRequest struct {
+	Count  int    `json:"count" binding:"min=1,max=10" example:"1"`
+	Remark string `json:"remark" binding:"max=8" example:"fast"`
+}
+
+type holeRequest struct {
+	Status string `json:"status" enum:"open,closed" example:"open"`
+}
+
+func newNegativeServer() (*swagger.ApiGroup, *gin.Engine) {
+	gine := gin.New()
+	group := swagger.NewAPIGroup()
+	swagger.RegisterAPI(group, gine, "POST", "/orders", func(ctx *gin.Context, req *orderRequest) *userResponse {
+		return &userResponse{}
+	})
+	swagger.RegisterAPI(group, gine, "POST", "/holes", func(ctx *gin.Context, req *holeRequest) *userResponse {
+		return &userResponse{}
+	})
+	return group, gine
+}
+
+func TestRunNegative(t *testing.T) {
+	group, gine := newNegativeServer()
+	RunNegative(t, group, gine, WithSkip(func(api *swagger.Api) bool {
+		return api.Route == "/holes"
+	}))
+}
+
+func TestCheckNegative(t *testing.T) {
+	group, gine := newNegativeServer()
+	errs := CheckNegative(group.Apis()[1], gine)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "body 'status' out of enum: expect 4xx, got 200") {
+		t.Fatalf("expect enum hole to be reported, got %v", errs)
+	}
+}
+
+func FuzzOrder(f *testing.F) {
+	group, gine := newNegativeServer()
+	Fuzz(f, group.Apis()[0], gine)
+}
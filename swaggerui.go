@@ -0,0 +1,68 @@
+package swagger
+
+import (
+	"embed"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"path"
+	"strings"
+)
+
+//go:embed swaggerui
+var swaggerUIFS embed.FS
+
+// HandlerSwaggerUI serves the embedded openapi viewer and the spec of the group for the audience
+// resolved by SetAudienceResolver under prefix, it works offline as all assets are embedded:
+//
+//	engine.GET("/swagger/*any", group.HandlerSwaggerUI("/swagger"))
+//
+// Specs of versioned apis are served per version by query "version" like HandlerOpenAPI.
+func (a *ApiGroup) HandlerSwaggerUI(prefix string) gin.HandlerFunc {
+	return a.HandlerSwaggerUIWithInfo(prefix, "API", "1.0.0")
+}
+
+// HandlerSwaggerUIWithInfo is HandlerSwaggerUI with the title and version of the spec,
+// versioned specs use the api version.
+func (a *ApiGroup) HandlerSwaggerUIWithInfo(prefix, title, version string) gin.HandlerFunc {
+	specs := map[string]map[Audience][]byte{}
+	for v, g := range a.versionViews() {
+		specs[v] = map[Audience][]byte{}
+		infoVersion := version
+		if v != "" {
+			infoVersion = v
+		}
+		for _, aud := range audiences {
+			spec, err := JsonMarshal(g.GenerateOpenAPI(title, infoVersion, aud))
+			if err != nil {
+				panic(err)
+			}
+			specs[v][aud] = spec
+		}
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+	return func(c *gin.Context) {
+		name := strings.TrimPrefix(strings.TrimPrefix(c.Request.URL.Path, prefix), "/")
+		switch name {
+		case "openapi.json":
+			spec, ok := specs[a.requestedVersion(c)]
+			if !ok {
+				abortWithStatusJson(c, 404, &ErrorResponse{Error: "unknown version"})
+				return
+			}
+			c.Data(200, "application/json; charset=utf-8", spec[a.audience(c)])
+			return
+		case "":
+			name = "index.html"
+		}
+		data, err := swaggerUIFS.ReadFile("swaggerui/" + name)
+		if err != nil {
+			abortWithStatusJson(c, 404, &ErrorResponse{Error: "not found"})
+			return
+		}
+		ctype := mime.TypeByExtension(path.Ext(name))
+		if ctype == "" {
+			ctype = "application/octet-stream"
+		}
+		c.Data(200, ctype, data)
+	}
+}
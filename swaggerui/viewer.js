@@ -0,0 +1,243 @@
+(function () {
+    "use strict";
+
+    const authKey = "swagger.auth";
+    const methods = ["get", "post", "put", "patch", "delete", "head", "options"];
+
+    function el(tag, attrs, children) {
+        const node = document.createElement(tag);
+        Object.entries(attrs || {}).forEach(([k, v]) => {
+            if (k === "class") {
+                node.className = v;
+            } else if (k.startsWith("on")) {
+                node.addEventListener(k.slice(2), v);
+            } else {
+                node.setAttribute(k, v);
+            }
+        });
+        (children || []).forEach(c => node.append(c instanceof Node ? c : document.createTextNode(String(c))));
+        return node;
+    }
+
+    function pretty(v) {
+        return typeof v === "string" ? v : JSON.stringify(v, null, 2);
+    }
+
+    function typeOf(schema) {
+        if (!schema) {
+            return "any";
+        }
+        if (schema.type === "array") {
+            return "array<" + typeOf(schema.items) + ">";
+        }
+        let t = schema.type || "any";
+        if (schema.enum) {
+            t += " [" + schema.enum.join(", ") + "]";
+        }
+        return t;
+    }
+
+    function renderSchema(schema) {
+        const root = el("div", {class: "schema"});
+        (function walk(s, parent) {
+            if (!s) {
+                return;
+            }
+            if (s.type === "array") {
+                walk(s.items, parent);
+                return;
+            }
+            const required = new Set(s.required || []);
+            Object.keys(s.properties || {}).sort().forEach(name => {
+                const p = s.properties[name];
+                const row = el("div", {class: "prop"}, [
+                    name,
+                    required.has(name) ? el("span", {class: "required"}, [" *"]) : "",
+                    " ",
+                    el("span", {class: "type"}, [typeOf(p)]),
+                    p.description ? el("span", {class: "desc"}, [" " + p.description]) : "",
+                    p.deprecated ? el("span", {class: "required"}, [" deprecated"]) : "",
+                ]);
+                parent.append(row);
+                walk(p, row);
+            });
+        })(schema, root);
+        return root;
+    }
+
+    function jsonBody(content) {
+        return content && (content["application/json"] || Object.values(content)[0]);
+    }
+
+    function renderOperation(spec, path, method, op) {
+        const box = el("div", {class: "op " + method + (op.deprecated ? " deprecated" : "")});
+        const head = el("div", {class: "op-head", onclick: () => box.classList.toggle("open")}, [
+            el("span", {class: "op-method"}, [method.toUpperCase()]),
+            el("span", {class: "op-path"}, [path]),
+            el("span", {class: "op-summary"}, [op.summary || ""]),
+        ]);
+        const body = el("div", {class: "op-body"});
+        box.append(head, body);
+        box.dataset.search = (path + " " + (op.summary || "")).toLowerCase();
+
+        if (op.description) {
+            body.append(el("p", {}, [op.description]));
+        }
+
+        const inputs = [];
+        body.append(el("h4", {}, ["Parameters"]));
+        if ((op.parameters || []).length === 0) {
+            body.append(el("div", {}, ["No parameters"]));
+        } else {
+            const tbody = el("tbody");
+            op.parameters.forEach(p => {
+                const input = el("input", {value: p.example === undefined ? "" : String(p.example)});
+                inputs.push({param: p, input: input});
+                tbody.append(el("tr", {}, [
+                    el("td", {}, [p.name, p.required ? el("span", {class: "required"}, [" *required"]) : ""]),
+                    el("td", {}, [el("span", {class: "type"}, [typeOf(p.schema)]), " (" + p.in + ")"]),
+                    el("td", {}, [p.description || ""]),
+                    el("td", {}, [input]),
+                ]));
+            });
+            body.append(el("table", {}, [
+                el("thead", {}, [el("tr", {}, ["Name", "Type", "Description", "Value"].map(h => el("th", {}, [h])))]),
+                tbody,
+            ]));
+        }
+
+        let bodyInput = null;
+        const reqBody = op.requestBody && jsonBody(op.requestBody.content);
+        if (reqBody) {
+            body.append(el("h4", {}, ["Request body"]), renderSchema(reqBody.schema));
+            bodyInput = el("textarea", {spellcheck: "false"});
+            bodyInput.value = reqBody.example === undefined ? "" : pretty(reqBody.example);
+            body.append(bodyInput);
+        }
+
+        body.append(el("h4", {}, ["Responses"]));
+        Object.keys(op.responses || {}).sort().forEach(code => {
+            const res = op.responses[code];
+            const media = jsonBody(res.content);
+            body.append(el("div", {}, [el("strong", {}, [code]), " " + (res.description || "")]));
+            if (media) {
+                body.append(renderSchema(media.schema));
+                if (media.example !== undefined) {
+                    body.append(el("pre", {}, [pretty(media.example)]));
+                }
+            }
+        });
+
+        const result = el("pre");
+        result.style.display = "none";
+        body.append(el("button", {class: "execute", onclick: () => execute(spec, path, method, inputs, bodyInput, result)}, ["Execute"]), result);
+        return box;
+    }
+
+    async function execute(spec, path, method, inputs, bodyInput, result) {
+        const query = new URLSearchParams();
+        const headers = {};
+        let url = path;
+        inputs.forEach(({param, input}) => {
+            const v = input.value;
+            if (param.in === "path") {
+                url = url.replace("{" + param.name + "}", encodeURIComponent(v).replace(/%2F/g, "/"));
+            } else if (v !== "" && param.in === "query") {
+                query.set(param.name, v);
+            } else if (v !== "" && param.in === "header") {
+                headers[param.name] = v;
+            }
+        });
+        const authName = document.getElementById("auth-name").value;
+        const authValue = document.getElementById("auth-value").value;
+        if (authName && authValue) {
+            headers[authName] = authValue;
+        }
+        const opts = {method: method.toUpperCase(), headers: headers};
+        if (bodyInput && bodyInput.value.trim() !== "") {
+            headers["Content-Type"] = "application/json";
+            opts.body = bodyInput.value;
+        }
+        const server = (spec.servers && spec.servers[0] && spec.servers[0].url) || "";
+        const qs = query.toString();
+        result.style.display = "block";
+        result.textContent = "loading...";
+        try {
+            const resp = await fetch(server + url + (qs ? "?" + qs : ""), opts);
+            let text = await resp.text();
+            try {
+                text = pretty(JSON.parse(text));
+            } catch (e) {
+            }
+            result.textContent = resp.status + " " + resp.statusText + "\n\n" + text;
+        } catch (e) {
+            result.textContent = String(e);
+        }
+    }
+
+    function render(spec) {
+        const app = document.getElementById("app");
+        document.title = spec.info.title;
+        document.getElementById("title").textContent = spec.info.title;
+        document.getElementById("version").textContent = spec.info.version;
+        if (spec.info.description) {
+            app.append(el("p", {}, [spec.info.description]));
+        }
+
+        const sections = new Map();
+        const section = name => {
+            if (!sections.has(name)) {
+                const box = el("div", {}, [el("div", {class: "section-title"}, [name])]);
+                sections.set(name, box);
+                app.append(box);
+            }
+            return sections.get(name);
+        };
+        Object.keys(spec.paths || {}).forEach(path => {
+            methods.forEach(method => {
+                const op = spec.paths[path][method];
+                if (op) {
+                    const name = (op.tags && op.tags[0]) || "default";
+                    section(name).append(renderOperation(spec, path, method, op));
+                }
+            });
+        });
+        Object.keys(spec.webhooks || {}).forEach(name => {
+            methods.forEach(method => {
+                const op = spec.webhooks[name][method];
+                if (op) {
+                    section("webhooks").append(renderOperation(spec, name, method, op));
+                }
+            });
+        });
+    }
+
+    function initAuth() {
+        const auth = JSON.parse(localStorage.getItem(authKey) || "{}");
+        const name = document.getElementById("auth-name");
+        const value = document.getElementById("auth-value");
+        name.value = auth.name || name.value;
+        value.value = auth.value || "";
+        const save = () => localStorage.setItem(authKey, JSON.stringify({name: name.value, value: value.value}));
+        name.addEventListener("change", save);
+        value.addEventListener("change", save);
+    }
+
+    function initFilter() {
+        document.getElementById("filter").addEventListener("input", e => {
+            const q = e.target.value.toLowerCase();
+            document.querySelectorAll(".op").forEach(op => {
+                op.style.display = op.dataset.search.includes(q) ? "" : "none";
+            });
+        });
+    }
+
+    initAuth();
+    initFilter();
+    fetch("openapi.json" + location.search)
+        .then(resp => resp.json())
+        .then(render)
+        .catch(e => {
+            document.getElementById("app").textContent = "load openapi.json error: " + e;
+        });
+})();
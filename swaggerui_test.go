@@ -0,0 +1,40 @@
+package swagger
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlerSwaggerUI(t *testing.T) {
+	group := NewAPIGroup()
+	gine := gin.New()
+	RegisterAPI(group, gine, "POST", "/hello/:key/*path", HandlerReq, WithTitle("hello"))
+	gine.GET("/swagger/*any", group.HandlerSwaggerUI("/swagger"))
+
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		gine.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w
+	}
+	if w := get("/swagger/"); w.Code != 200 || !strings.Contains(w.Body.String(), `<script src="viewer.js">`) {
+		t.Fatalf("index is not served: %d", w.Code)
+	}
+	if w := get("/swagger/viewer.js"); w.Code != 200 || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/javascript") {
+		t.Fatalf("viewer.js is not served: %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	w := get("/swagger/openapi.json")
+	spec := &OpenAPI{}
+	if err := json.Unmarshal(w.Body.Bytes(), spec); err != nil {
+		t.Fatal(err)
+	}
+	op := spec.Paths["/hello/{key}/{path}"]["post"]
+	if op == nil || len(op.Parameters) == 0 || op.RequestBody == nil {
+		t.Fatalf("unexpected spec: %s", w.Body.String())
+	}
+	if w := get("/swagger/missing.js"); w.Code != 404 {
+		t.Fatalf("expect 404, got %d", w.Code)
+	}
+}
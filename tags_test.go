@@ -0,0 +1,50 @@
+package swagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"strings"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	RegisterAPI(group, gine, "GET", "/health", HandlerReq)
+	group.SetDefaultTags("user")
+	RegisterAPI(group, gine, "POST", "/users", HandlerReq, WithTitle("create user"))
+	RegisterAPI(group, gine, "GET", "/orders", HandlerReq, WithTitle("list orders"), WithTags("order", "user"))
+
+	if tags := group.Apis()[1].Tags; len(tags) != 1 || tags[0] != "user" {
+		t.Fatalf("default tags should be applied, got %v", tags)
+	}
+
+	md := group.GenerateMarkdown()
+	for _, s := range []string{
+		"- default\n  - [0 ](#api-0)",
+		"- user\n  - [1 create user](#api-1)\n  - [2 list orders](#api-2)",
+		"- order\n  - [2 list orders](#api-2)",
+		"### user\n\n<a id=\"api-1\"></a>\n#### 1 create user",
+		"### order\n\n<a id=\"api-2\"></a>\n#### 2 list orders",
+	} {
+		if !strings.Contains(md, s) {
+			t.Errorf("markdown should contain %q:\n%s", s, md)
+		}
+	}
+	if strings.Count(md, "#### 2 list orders") != 1 {
+		t.Error("api should be rendered once under its first tag")
+	}
+
+	html := group.GenerateHtml()
+	if !strings.Contains(html, `<summary class="nav-group-title">user<span class="nav-count">2</span></summary>`) {
+		t.Error("html sidebar should be grouped by tags")
+	}
+
+	spec := group.GenerateOpenAPI("api", "1.0.0")
+	if len(spec.Tags) != 2 || spec.Tags[0].Name != "user" || spec.Paths["/orders"]["get"].Tags[0] != "order" {
+		t.Errorf("unexpected openapi tags: %+v", spec.Tags)
+	}
+	col := group.GeneratePostmanCollection()
+	if len(col.Item) != 3 || col.Item[2].Name != "order" {
+		t.Errorf("postman items should be grouped by tags: %+v", col.Item)
+	}
+}
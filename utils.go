@@ -1,6 +1,10 @@
 package swagger
 
-import "github.com/bytedance/sonic"
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/bytedance/sonic"
+)
 
 var (
 	jsonEnc = sonic.Config{
@@ -15,3 +19,11 @@ func JsonMarshal(v interface{}) ([]byte, error) {
 func JsonUnmarshal(data []byte, v interface{}) error {
 	return jsonEnc.Unmarshal(data, v)
 }
+
+func randomId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
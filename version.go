@@ -0,0 +1,211 @@
+package swagger
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"path"
+	"strings"
+)
+
+// VersionMode decides how requests select an api version
+type VersionMode int
+
+const (
+	// VersionByPath routes versions by a path segment after the router base path, e.g. /api/v2/users
+	VersionByPath VersionMode = iota
+	// VersionByHeader routes requests of the unversioned path by Versioning.Header,
+	// versioned paths are still served.
+	VersionByHeader
+	// VersionByMediaType routes requests of the unversioned path by the vendor media type of Accept header,
+	// e.g. application/vnd.example.v2+json, versioned paths are still served.
+	VersionByMediaType
+)
+
+const DefaultVersionHeader = "X-API-Version"
+
+// Versioning configures how requests select a version of apis registered with versions
+type Versioning struct {
+	Mode VersionMode
+	// Header carries the version in VersionByHeader mode, DefaultVersionHeader is used if empty
+	Header string
+	// MediaType is the vendor media type without version in VersionByMediaType mode, e.g. application/vnd.example
+	MediaType string
+	// Default is used when a request names no version, the last registered version of the route is used if empty
+	Default string
+	// Engine dispatches requests of unversioned paths to versioned routes in header and media type mode
+	Engine *gin.Engine
+}
+
+// WithVersions registers the api for each version, the returned api is the one of the first version
+func WithVersions(versions ...string) OptFunc {
+	return func(o *Api) {
+		o.Versions = append(o.Versions, versions...)
+	}
+}
+
+// SetVersion sets the version of apis registered later without WithVersions
+func (a *ApiGroup) SetVersion(version string) {
+	a.version = version
+}
+
+// SetVersioning sets how versioned apis registered later are routed, VersionByPath is used by default
+func (a *ApiGroup) SetVersioning(v *Versioning) {
+	if v.Mode != VersionByPath && v.Engine == nil {
+		panic("swagger: Versioning.Engine is required to route versions by header or media type")
+	}
+	if v.Header == "" {
+		v.Header = DefaultVersionHeader
+	}
+	a.versioning = v
+}
+
+// Versions returns the api versions in order of registration
+func (a *ApiGroup) Versions() []string {
+	versions := []string{}
+	seen := map[string]bool{}
+	for _, api := range a.apis {
+		for _, v := range api.Versions {
+			if !seen[v] {
+				seen[v] = true
+				versions = append(versions, v)
+			}
+		}
+	}
+	return versions
+}
+
+// VersionGroup returns a view of the group with the apis of version only, to generate docs and specs per version
+func (a *ApiGroup) VersionGroup(version string) *ApiGroup {
+	g := &ApiGroup{webhooks: a.webhooks, vad: a.vad, tags: a.tags, audienceResolver: a.audienceResolver,
+		version: version, versions: a.Versions(), versioning: a.versioning,
+		title: a.title, description: a.description, children: a.children}
+	for _, api := range a.apis {
+		for _, v := range api.Versions {
+			if v == version {
+				g.apis = append(g.apis, api)
+				break
+			}
+		}
+	}
+	return g
+}
+
+// versionViews returns the views served by doc handlers keyed by version, the group itself keyed by "" if unversioned
+func (a *ApiGroup) versionViews() map[string]*ApiGroup {
+	versions := a.Versions()
+	if len(versions) == 0 {
+		return map[string]*ApiGroup{"": a}
+	}
+	views := map[string]*ApiGroup{}
+	for _, v := range versions {
+		views[v] = a.VersionGroup(v)
+	}
+	return views
+}
+
+// requestedVersion returns the version of docs by query "version", Versioning.Default or the last version by default
+func (a *ApiGroup) requestedVersion(c *gin.Context) string {
+	if v := c.Query("version"); v != "" {
+		return v
+	}
+	versions := a.Versions()
+	if len(versions) == 0 {
+		return ""
+	}
+	if a.versioning != nil && a.versioning.Default != "" {
+		return a.versioning.Default
+	}
+	return versions[len(versions)-1]
+}
+
+// handleVersions registers a copy of api for each version
+func (a *ApiGroup) handleVersions(router BasicRouter, api *Api, pth string, handler gin.HandlerFunc) {
+	orig := *api
+	for i, v := range orig.Versions {
+		va := api
+		if i > 0 {
+			cp := orig
+			va = &cp
+		}
+		va.Versions = []string{v}
+		a.route(router, va, path.Join("/", v, pth), handler)
+
+		vs := a.versioning
+		if vs == nil || vs.Mode == VersionByPath {
+			continue
+		}
+		header, example := vs.Header, v
+		if vs.Mode == VersionByMediaType {
+			header, example = "Accept", vs.MediaType+"."+v+"+json"
+		}
+		rs := *va.RequestSchema
+		rs.Properties = map[string]*Schema{}
+		for name, p := range va.RequestSchema.Properties {
+			rs.Properties[name] = p
+		}
+		rs.Properties[header] = &Schema{Type: "string", Location: "header", Example: example, Enum: []string{example}, Description: "api version"}
+		va.RequestSchema = &rs
+		va.Route = path.Join(router.BasePath(), pth)
+		a.dispatchVersion(router, va, pth, v)
+	}
+}
+
+// versionRoute is an unversioned route dispatching to versioned routes
+type versionRoute struct {
+	base     string
+	versions []string
+}
+
+func (a *ApiGroup) dispatchVersion(router BasicRouter, api *Api, pth string, version string) {
+	key := api.Method + " " + api.Route
+	root := a.rootGroup()
+	if root.versionRoutes == nil {
+		root.versionRoutes = map[string]*versionRoute{}
+	}
+	vr := root.versionRoutes[key]
+	if vr != nil {
+		vr.versions = append(vr.versions, version)
+		return
+	}
+	vr = &versionRoute{base: router.BasePath(), versions: []string{version}}
+	root.versionRoutes[key] = vr
+	vs := a.versioning
+	router.Handle(api.Method, pth, func(c *gin.Context) {
+		v := vs.requestVersion(c)
+		if v == "" {
+			v = vs.Default
+		}
+		if v == "" {
+			v = vr.versions[len(vr.versions)-1]
+		}
+		found := false
+		for _, rv := range vr.versions {
+			found = found || rv == v
+		}
+		if !found {
+			abortWithStatusJson(c, 400, &ErrorResponse{Error: fmt.Sprintf("api version '%s' is not supported", v)})
+			return
+		}
+		rest := strings.TrimPrefix(c.Request.URL.Path, strings.TrimSuffix(vr.base, "/"))
+		c.Request.URL.Path = path.Join(vr.base, v) + rest
+		c.Request.URL.RawPath = ""
+		vs.Engine.HandleContext(c)
+		c.Abort()
+	})
+}
+
+// requestVersion returns the version named by header or Accept media type
+func (v *Versioning) requestVersion(c *gin.Context) string {
+	if v.Mode == VersionByHeader {
+		return c.GetHeader(v.Header)
+	}
+	prefix := v.MediaType + "."
+	for _, accept := range strings.Split(c.GetHeader("Accept"), ",") {
+		mt, _, _ := strings.Cut(strings.TrimSpace(accept), ";")
+		if strings.HasPrefix(mt, prefix) {
+			ver, _, _ := strings.Cut(strings.TrimPrefix(mt, prefix), "+")
+			return ver
+		}
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+package swagger
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type versionResponse struct {
+	Version string `json:"version"`
+}
+
+func TestVersionByPath(t *testing.T) {
+	gine := gin.New()
+	group := NewAPIGroup()
+	api := gine.Group("/api")
+	RegisterAPI(group, api, "GET", "/users", func(ctx *gin.Context, req *struct{}) *versionResponse {
+		return &versionResponse{Version: "shared"}
+	}, WithTitle("list users"), WithVersions("v1", "v2"))
+	group.SetVersion("v2")
+	RegisterAPI(group, api, "POST", "/users", func(ctx *gin.Context, req *struct{}) *versionResponse {
+		return &versionResponse{Version: "v2"}
+	}, WithTitle("create user"))
+
+	for _, url := range []string{"/api/v1/users", "/api/v2/users"} {
+		w := httptest.NewRecorder()
+		gine.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		if w.Code != 200 {
+			t.Errorf("%s should be served, got %d", url, w.Code)
+		}
+	}
+	if vs := group.Versions(); len(vs) != 2 || vs[0] != "v1" || vs[1] != "v2" {
+		t.Errorf("unexpected versions: %v", vs)
+	}
+	if apis := group.VersionGroup("v1").Apis(); len(apis) != 1 || apis[0].Route != "/api/v1/users" {
+		t.Errorf("unexpected v1 apis: %v", apis)
+	}
+	if md := group.VersionGroup("v2").GenerateMarkdown(); !strings.Contains(md, "**版本**: v2 (全部版本: v1, v2)") ||
+		!strings.Contains(md, "POST /api/v2/users") || strings.Contains(md, "/api/v1/users") {
+		t.Errorf("unexpected v2 markdown:\n%s", md)
+	}
+
+	gine.GET("/apidoc.html", group.HandlerDocumentHtml())
+	gine.GET("/openapi.json", group.HandlerOpenAPI("api", "1.0.0"))
+	w := httptest.NewRecorder()
+	gine.ServeHTTP(w, httptest.NewRequest("GET", "/apidoc.html", nil))
+	if body := w.Body.String(); !strings.Contains(body, `<option value="v2" selected>`) || strings.Contains(body, "/api/v1/users") {
+		t.Error("html docs should serve the latest version with a version switcher")
+	}
+	w = httptest.NewRecorder()
+	gine.ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json?version=v1", nil))
+	spec := &OpenAPI{}
+	_ = json.Unmarshal(w.Body.Bytes(), spec)
+	if spec.Info.Version != "v1" || len(spec.Paths) != 1 || spec.Paths["/api/v1/users"] == nil {
+		t.Errorf("unexpected v1 spec: %s", w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	gine.ServeHTTP(w, httptest.NewRequest("GET", "/apidoc.html?version=v3", nil))
+	if w.Code != 404 {
+		t.Errorf("unknown version should be 404, got %d", w.Code)
+	}
+}
+
+func TestVersionByHeader(t *testing.T) {
+	for _, tc := range []struct {
+		versioning *Versioning
+		header     string
+		value      string
+	}{
+		{&Versioning{Mode: VersionByHeader}, DefaultVersionHeader, "v1"},
+		{&Versioning{Mode: VersionByMediaType, MediaType: "application/vnd.example"}, "Accept", "application/vnd.example.v1+json"},
+	} {
+		gine := gin.New()
+		group := NewAPIGroup()
+		tc.versioning.Engine = gine
+		group.SetVersioning(tc.versioning)
+		RegisterAPI(group, gine, "GET", "/users/:id", func(ctx *gin.Context, req *struct{}) *versionResponse {
+			return &versionResponse{Version: "v1 " + ctx.Param("id")}
+		}, WithVersions("v1"))
+		RegisterAPI(group, gine, "GET", "/users/:id", func(ctx *gin.Context, req *struct{}) *versionResponse {
+			return &versionResponse{Version: "v2 " + ctx.Param("id")}
+		}, WithVersions("v2"))
+
+		get := func(value string) (int, string) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/users/7", nil)
+			if value != "" {
+				r.Header.Set(tc.header, value)
+			}
+			gine.ServeHTTP(w, r)
+			return w.Code, w.Body.String()
+		}
+		if _, body := get(tc.value); !strings.Contains(body, `"v1 7"`) {
+			t.Errorf("%s %s should route to v1: %s", tc.header, tc.value, body)
+		}
+		if _, body := get(""); !strings.Contains(body, `"v2 7"`) {
+			t.Errorf("requests without version should route to the latest: %s", body)
+		}
+		if code, _ := get(strings.Replace(tc.value, "v1", "v9", 1)); code != 400 {
+			t.Errorf("unknown version should be 400, got %d", code)
+		}
+		v1 := group.VersionGroup("v1").Apis()[0]
+		if v1.Route != "/users/:id" || v1.RequestSchema.Properties[tc.header].Example != tc.value {
+			t.Errorf("unexpected v1 api doc: %s %v", v1.Route, v1.RequestSchema.Properties[tc.header])
+		}
+
+		gine.GET("/swagger/*any", group.HandlerSwaggerUIWithInfo("/swagger", "users", "1.0.0"))
+		for _, v := range []string{"v1", "v2"} {
+			w := httptest.NewRecorder()
+			gine.ServeHTTP(w, httptest.NewRequest("GET", "/swagger/openapi.json?version="+v, nil))
+			spec := &OpenAPI{}
+			_ = json.Unmarshal(w.Body.Bytes(), spec)
+			op := spec.Paths["/users/{id}"]["get"]
+			if spec.Info.Title != "users" || spec.Info.Version != v || op == nil ||
+				!strings.Contains(string(mustJson(op.Parameters)), strings.Replace(tc.value, "v1", v, 1)) {
+				t.Errorf("swagger ui should serve the %s spec: %s", v, w.Body.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookIdHeader        = "X-Webhook-Id"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// DefaultWebhookTolerance is the max age of the webhook timestamp accepted by VerifyWebhook
+const DefaultWebhookTolerance = 5 * time.Minute
+
+type Webhook struct {
+	Name          string  `json:"name"`
+	Title         string  `json:"title"`
+	Description   string  `json:"description"`
+	Payload       any     `json:"-"`
+	PayloadSchema *Schema `json:"payload_schema,omitempty"`
+}
+
+// WebhookEvent is a registered webhook with a typed payload.
+type WebhookEvent[T any] struct {
+	*Webhook
+}
+
+func (e *WebhookEvent[T]) Send(ctx context.Context, s *WebhookSender, url string, payload *T) error {
+	return s.Send(ctx, url, e.Name, payload)
+}
+
+func RegisterWebhook[T any](a *ApiGroup, name string, title string, desc string) *WebhookEvent[T] {
+	sc := generateSchema(reflect.ValueOf(new(T)), "")
+	sc.Description = desc
+	w := &Webhook{
+		Name:          name,
+		Title:         title,
+		Description:   desc,
+		Payload:       new(T),
+		PayloadSchema: sc,
+	}
+	if w.Title == "" {
+		w.Title = name
+	}
+	a.webhooks = append(a.webhooks, w)
+	return &WebhookEvent[T]{Webhook: w}
+}
+
+type WebhookSender struct {
+	Secret     []byte
+	Client     *http.Client
+	MaxRetries int
+	// Backoff returns the wait time before retry attempt n (starting at 0)
+	Backoff func(n int) time.Duration
+}
+
+func NewWebhookSender(secret string) *WebhookSender {
+	return &WebhookSender{
+		Secret:     []byte(secret),
+		Client:     http.DefaultClient,
+		MaxRetries: 3,
+		Backoff:    defaultWebhookBackoff,
+	}
+}
+
+func defaultWebhookBackoff(n int) time.Duration {
+	d := 500 * time.Millisecond << n
+	if d > 30*time.Second || d <= 0 {
+		d = 30 * time.Second
+	}
+	return d
+}
+
+type WebhookError struct {
+	Event      string
+	StatusCode int
+	Attempts   int
+	Err        error
+}
+
+func (e *WebhookError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("webhook %s delivery failed after %d attempts: %v", e.Event, e.Attempts, e.Err)
+	}
+	return fmt.Sprintf("webhook %s delivery failed after %d attempts: status %d", e.Event, e.Attempts, e.StatusCode)
+}
+
+func (e *WebhookError) Unwrap() error {
+	return e.Err
+}
+
+// Send posts the json payload to url. 5xx, 429 and transport errors are retried
+// up to MaxRetries times, other non-2xx statuses fail immediately.
+func (s *WebhookSender) Send(ctx context.Context, url string, event string, payload any) error {
+	body, err := JsonMarshal(payload)
+	if err != nil {
+		return err
+	}
+	id, err := randomId()
+	if err != nil {
+		return err
+	}
+	backoff := s.Backoff
+	if backoff == nil {
+		backoff = defaultWebhookBackoff
+	}
+	for attempt := 0; ; attempt++ {
+		status, err := s.deliver(ctx, url, event, id, body)
+		if err == nil && status >= 200 && status < 300 {
+			return nil
+		}
+		retry := err != nil || status >= 500 || status == http.StatusTooManyRequests
+		if !retry || attempt >= s.MaxRetries {
+			return &WebhookError{Event: event, StatusCode: status, Attempts: attempt + 1, Err: err}
+		}
+		select {
+		case <-ctx.Done():
+			return &WebhookError{Event: event, StatusCode: status, Attempts: attempt + 1, Err: ctx.Err()}
+		case <-time.After(backoff(attempt)):
+		}
+	}
+}
+
+func (s *WebhookSender) deliver(ctx context.Context, url, event, id string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookIdHeader, id)
+	req.Header.Set(WebhookTimestampHeader, ts)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(s.Secret, ts, body))
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// SignWebhook returns the signature header value: sha256=hex(hmac(secret, timestamp + "." + body))
+func SignWebhook(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks the signature of a received webhook request and returns its body,
+// requests with a timestamp older or newer than DefaultWebhookTolerance are rejected as replays.
+func VerifyWebhook(secret []byte, r *http.Request) ([]byte, error) {
+	return VerifyWebhookWithTolerance(secret, r, DefaultWebhookTolerance)
+}
+
+// VerifyWebhookWithTolerance is VerifyWebhook rejecting timestamps differing from now by more than tolerance,
+// tolerance <= 0 skips the age check but the timestamp is still required.
+func VerifyWebhookWithTolerance(secret []byte, r *http.Request, tolerance time.Duration) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	sig := r.Header.Get(WebhookSignatureHeader)
+	if !strings.HasPrefix(sig, "sha256=") {
+		return nil, errors.New("webhook signature is missing")
+	}
+	ts := r.Header.Get(WebhookTimestampHeader)
+	if ts == "" {
+		return nil, errors.New("webhook timestamp is missing")
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return nil, errors.New("webhook timestamp is invalid")
+	}
+	expect := SignWebhook(secret, ts, body)
+	if !hmac.Equal([]byte(sig), []byte(expect)) {
+		return nil, errors.New("webhook signature mismatch")
+	}
+	if age := time.Since(time.Unix(unix, 0)); tolerance > 0 && (age > tolerance || age < -tolerance) {
+		return nil, errors.New("webhook timestamp is out of tolerance")
+	}
+	return body, nil
+}
+
+func (a *ApiGroup) Webhooks() []*Webhook {
+	return a.webhooks
+}
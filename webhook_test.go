@@ -0,0 +1,103 @@
+package swagger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type orderPaid struct {
+	OrderId string `json:"order_id" example:"o-1" desc:"order id"`
+	Amount  int    `json:"amount" example:"100"`
+	Status  string `json:"status" enum:"paid,refunded"`
+}
+
+func TestWebhookSend(t *testing.T) {
+	group := NewAPIGroup()
+	evt := RegisterWebhook[orderPaid](group, "order.paid", "order paid", "sent when an order is paid")
+
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(500)
+			return
+		}
+		body, err := VerifyWebhook([]byte("secret"), r)
+		if err != nil {
+			w.WriteHeader(401)
+			return
+		}
+		if r.Header.Get(WebhookEventHeader) != "order.paid" {
+			t.Errorf("unexpected event header: %s", r.Header.Get(WebhookEventHeader))
+		}
+		p := &orderPaid{}
+		if err := json.Unmarshal(body, p); err != nil || p.OrderId != "o-1" {
+			t.Errorf("unexpected payload: %s %v", body, err)
+		}
+	}))
+	defer srv.Close()
+
+	sender := NewWebhookSender("secret")
+	sender.Backoff = func(n int) time.Duration { return time.Millisecond }
+	if err := evt.Send(context.Background(), sender, srv.URL, &orderPaid{OrderId: "o-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("expect 2 attempts, got %d", calls)
+	}
+
+	sender.Secret = []byte("wrong")
+	err := evt.Send(context.Background(), sender, srv.URL, &orderPaid{OrderId: "o-1"})
+	if we, ok := err.(*WebhookError); !ok || we.StatusCode != 401 || we.Attempts != 1 {
+		t.Fatalf("expect non retried 401 error, got %v", err)
+	}
+
+	if md := group.GenerateMarkdown(); !strings.Contains(md, "order.paid") {
+		t.Fatal("webhook is not documented in markdown")
+	}
+	spec := group.GenerateOpenAPI("test", "1.0")
+	if spec.Webhooks["order.paid"]["post"].RequestBody == nil {
+		t.Fatal("webhook is not documented in openapi")
+	}
+}
+
+func TestVerifyWebhookTimestamp(t *testing.T) {
+	secret := []byte("secret")
+	body := `{"order_id":"o-1"}`
+	request := func(ts string) *http.Request {
+		r := httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+		if ts != "" {
+			r.Header.Set(WebhookTimestampHeader, ts)
+		}
+		r.Header.Set(WebhookSignatureHeader, SignWebhook(secret, ts, []byte(body)))
+		return r
+	}
+	unix := func(d time.Duration) string {
+		return strconv.FormatInt(time.Now().Add(d).Unix(), 10)
+	}
+	if _, err := VerifyWebhook(secret, request(unix(-time.Minute))); err != nil {
+		t.Errorf("recent webhook should be accepted: %v", err)
+	}
+	for name, ts := range map[string]string{
+		"missing": "",
+		"invalid": "yesterday",
+		"stale":   unix(-time.Hour),
+		"future":  unix(time.Hour),
+	} {
+		if _, err := VerifyWebhook(secret, request(ts)); err == nil {
+			t.Errorf("%s timestamp should be rejected", name)
+		}
+	}
+	if _, err := VerifyWebhookWithTolerance(secret, request(unix(-time.Hour)), 2*time.Hour); err != nil {
+		t.Errorf("timestamp within tolerance should be accepted: %v", err)
+	}
+	if _, err := VerifyWebhookWithTolerance(secret, request(""), 0); err == nil {
+		t.Error("timestamp should be required without tolerance")
+	}
+}